@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -13,9 +14,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"api-gateway/internal/auth"
 	grpcClients "api-gateway/internal/grpc"
 	"api-gateway/internal/handlers"
 	authMiddleware "api-gateway/internal/middleware"
+	"api-gateway/internal/proxy"
+	"api-gateway/internal/ratelimit"
+	"api-gateway/shared/jwtauth"
 	"api-gateway/shared/logger"
 	"api-gateway/shared/middleware"
 )
@@ -30,7 +35,69 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// gRPC Service configuration
+	// Token lifetimes of sessions refreshed by the gateway
+	accessTokenTTL := getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+	refreshTokenTTL := getEnvDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour)
+
+	// Token revocation
+	revocations, err := newRevocationList(refreshTokenTTL)
+	if err != nil {
+		zap.L().Fatal("Failed to configure token revocation", zap.Error(err))
+	}
+	defer revocations.Close()
+
+	// Token verification
+	verifierConfig, err := newVerifierConfig()
+	if err != nil {
+		zap.L().Fatal("Failed to configure token verification", zap.Error(err))
+	}
+	if verifierConfig.JWKS != nil {
+		// Reload the key set in the background so rotated keys are picked up
+		jwksCtx, stopJWKS := context.WithCancel(context.Background())
+		defer stopJWKS()
+		go verifierConfig.JWKS.Run(jwksCtx, getEnvDuration("JWT_JWKS_REFRESH_INTERVAL", jwtauth.DefaultRefreshInterval))
+	}
+	verifierConfig.Revocations = revocations
+	// Verified tokens are cached by hash when TOKEN_CACHE_TTL is set
+	verifierConfig.CacheTTL = getEnvDuration("TOKEN_CACHE_TTL", 0)
+
+	verifier, err := auth.NewVerifier(verifierConfig)
+	if err != nil {
+		zap.L().Fatal("Failed to configure token verification", zap.Error(err))
+	}
+
+	// Refresh tokens are signed with the secret shared with the auth service
+	var issuer *auth.TokenIssuer
+	if verifierConfig.HMACSecret != "" {
+		issuer = auth.NewTokenIssuer(auth.IssuerConfig{
+			Secret:          verifierConfig.HMACSecret,
+			Issuer:          verifierConfig.Issuer,
+			Audience:        verifierConfig.Audience,
+			AccessTokenTTL:  accessTokenTTL,
+			RefreshTokenTTL: refreshTokenTTL,
+			MaxSessionAge:   getEnvDuration("SESSION_MAX_AGE", 24*time.Hour),
+		})
+	} else {
+		zap.L().Warn("JWT_SECRET is not set, token refresh is disabled")
+	}
+
+	// Rate limiting
+	rateLimits, err := newRateLimitStore()
+	if err != nil {
+		zap.L().Fatal("Failed to configure rate limiting", zap.Error(err))
+	}
+	defer rateLimits.Close()
+
+	// Failed login tracking
+	loginFailures, err := newLoginFailureStore()
+	if err != nil {
+		zap.L().Fatal("Failed to configure login failure tracking", zap.Error(err))
+	}
+	defer loginFailures.Close()
+	loginGuard := auth.NewLoginGuard(loginFailures, grpcClients.EmailLoginLockout, grpcClients.IPLoginLockout)
+
+	// gRPC Service configuration. An address may list several replicas
+	// separated by commas, or be a dns:/// or file:/// target.
 	grpcConfig := grpcClients.GRPCConfig{
 		AuthServiceAddr:         getEnv("AUTH_GRPC_ADDR", "localhost:50051"),
 		ContractServiceAddr:     getEnv("CONTRACT_GRPC_ADDR", "localhost:50052"),
@@ -38,9 +105,12 @@ func main() {
 		DisputeServiceAddr:      getEnv("DISPUTE_GRPC_ADDR", "localhost:50054"),
 		NotificationServiceAddr: getEnv("NOTIFICATION_GRPC_ADDR", "localhost:50055"),
 		AuditServiceAddr:        getEnv("AUDIT_GRPC_ADDR", "localhost:50056"),
+		Balancer:                getEnv("GRPC_LB_POLICY", grpcClients.BalancerRoundRobin),
+		DisableHealthCheck:      os.Getenv("GRPC_HEALTH_CHECK") == "false",
 	}
 
-	// Initialize gRPC clients
+	// Initialize gRPC clients. Backends are connected in the background, so
+	// only an invalid address stops the gateway from starting.
 	grpcClientManager, err := grpcClients.NewGRPCClients(grpcConfig)
 	if err != nil {
 		zap.L().Fatal("Failed to initialize gRPC clients", zap.Error(err))
@@ -48,13 +118,28 @@ func main() {
 	defer grpcClientManager.Close()
 
 	// Initialize handlers
-	grpcProxyHandler := grpcClients.NewGRPCProxyHandler(grpcClientManager)
+	grpcProxyHandler := grpcClients.NewGRPCProxyHandler(grpcClientManager, rateLimits)
+	sessionHandler := grpcClients.NewSessionHandler(grpcClientManager, verifier, issuer, revocations, loginGuard)
 	healthHandler := handlers.NewHealthHandler()
+	tokenHandler := handlers.NewTokenHandler(verifier)
+	wsProxy := proxy.NewWebSocketProxy(
+		getEnv("NOTIFICATION_WS_URL", "ws://localhost:8081/ws"),
+		getEnvList("WS_ALLOWED_ORIGINS"),
+		verifier,
+	)
+	sseProxy := proxy.NewSSEProxy(getEnv("NOTIFICATION_SSE_URL", "http://localhost:8081/events"), verifier)
 
 	// Setup router
 	router := gin.New()
 
+	// Only trust X-Forwarded-For from known proxies, since rate limits of
+	// unauthenticated routes are keyed by client IP
+	if err := router.SetTrustedProxies(getEnvList("TRUSTED_PROXIES")); err != nil {
+		zap.L().Fatal("Invalid trusted proxies", zap.Error(err))
+	}
+
 	// Middleware
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger())
 	router.Use(middleware.ErrorHandler())
 	router.Use(cors.New(cors.Config{
@@ -67,41 +152,38 @@ func main() {
 
 	// Health check endpoint
 	router.GET("/api/v1/health", healthHandler.HealthCheck)
+	router.GET("/api/v1/health/backends", grpcProxyHandler.BackendHealth)
 
 	// Auth routes (no authentication required)
 	authGroup := router.Group("/api/v1/auth")
-	{
-		authGroup.POST("/register", grpcProxyHandler.Register)
-		authGroup.POST("/login", grpcProxyHandler.Login)
-		authGroup.POST("/validate", grpcProxyHandler.ValidateToken)
+	authGroup.Use(authMiddleware.RateLimit(rateLimits, grpcClients.IPRateLimit))
+	authGroup.POST("/validate", authMiddleware.RateLimit(rateLimits, grpcClients.ValidateRateLimit), tokenHandler.ValidateToken)
+	authGroup.POST("/login", authMiddleware.RateLimit(rateLimits, grpcClients.LoginRateLimit), sessionHandler.Login)
+	authGroup.POST("/refresh", authMiddleware.RateLimit(rateLimits, grpcClients.RefreshRateLimit), sessionHandler.Refresh)
+	authGroup.POST("/logout", authMiddleware.AuthMiddleware(verifier), sessionHandler.Logout)
+	if err := grpcProxyHandler.RegisterRoutes(authGroup, grpcClients.AuthRoutes); err != nil {
+		zap.L().Fatal("Failed to register auth routes", zap.Error(err))
 	}
 
 	// Protected routes (authentication required)
 	protected := router.Group("/api/v1")
-	protected.Use(authMiddleware.AuthMiddleware())
-	{
-		// User routes
-		protected.GET("/users/:userId", grpcProxyHandler.GetUser)
-
-		// Contract routes
-		protected.POST("/contracts", grpcProxyHandler.CreateContract)
-		protected.GET("/contracts", grpcProxyHandler.GetContracts)
-		protected.GET("/contracts/:contractId", grpcProxyHandler.GetContract)
-
-		// Payment routes
-		protected.POST("/wallets", grpcProxyHandler.CreateWallet)
-		protected.POST("/transfers", grpcProxyHandler.CreateTransfer)
-
-		// Dispute routes
-		protected.POST("/disputes", grpcProxyHandler.CreateDispute)
+	// Keyed by client IP since no user is known before the token is verified
+	protected.Use(authMiddleware.RateLimit(rateLimits, grpcClients.IPRateLimit))
+	protected.Use(authMiddleware.AuthMiddleware(verifier))
+	protected.Use(authMiddleware.RateLimit(rateLimits, grpcClients.APIRateLimit))
+	if err := grpcProxyHandler.RegisterRoutes(protected, grpcClients.APIRoutes); err != nil {
+		zap.L().Fatal("Failed to register API routes", zap.Error(err))
+	}
 
-		// Notification routes
-		protected.GET("/notifications", grpcProxyHandler.GetNotifications)
-		protected.PUT("/notifications/:notificationId/read", grpcProxyHandler.MarkNotificationAsRead)
+	// Gateway administration
+	protected.GET("/admin/circuit-breakers",
+		authMiddleware.RequirePermission(authMiddleware.PermissionGatewayAdmin),
+		grpcProxyHandler.CircuitBreakers,
+	)
 
-		// Audit routes (admin only in real implementation)
-		protected.GET("/audit/logs", grpcProxyHandler.GetAuditLogs)
-	}
+	// Real-time notifications, relayed to the notification service
+	protected.GET("/ws", wsProxy.ServeWS)
+	protected.GET("/notifications/stream", sseProxy.ServeSSE)
 
 	// Get port from environment
 	port := getEnv("PORT", "8080")
@@ -155,3 +237,90 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		zap.L().Fatal("Invalid duration", zap.String("key", key), zap.String("value", value))
+	}
+	return duration
+}
+
+// newRateLimitStore shares rate limit buckets through Redis when REDIS_URL is
+// set, otherwise limits apply per gateway instance.
+func newRateLimitStore() (ratelimit.Store, error) {
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		zap.L().Warn("REDIS_URL is not set, rate limits are kept in memory")
+		return ratelimit.NewMemoryStore(), nil
+	}
+	return ratelimit.NewRedisStore(redisURL)
+}
+
+// newLoginFailureStore shares failed login counts through Redis when
+// REDIS_URL is set, otherwise they are counted per gateway instance.
+func newLoginFailureStore() (auth.FailureStore, error) {
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		zap.L().Warn("REDIS_URL is not set, failed logins are counted in memory")
+		return auth.NewMemoryFailureStore(), nil
+	}
+	return auth.NewRedisFailureStore(redisURL)
+}
+
+// newVerifierConfig configures JWT verification from JWT_JWKS_URL or
+// JWT_JWKS_FILE for RS256/ES256 tokens and JWT_SECRET for HS256 tokens.
+// Outside release mode a development secret is used if neither is set.
+func newVerifierConfig() (auth.VerifierConfig, error) {
+	config := auth.VerifierConfig{Config: jwtauth.Config{
+		HMACSecret: os.Getenv("JWT_SECRET"),
+		Issuer:     os.Getenv("JWT_ISSUER"),
+		Audience:   os.Getenv("JWT_AUDIENCE"),
+	}}
+
+	var err error
+	if jwksURL := os.Getenv("JWT_JWKS_URL"); jwksURL != "" {
+		config.JWKS, err = jwtauth.NewJWKSFromURL(jwksURL)
+	} else if jwksFile := os.Getenv("JWT_JWKS_FILE"); jwksFile != "" {
+		config.JWKS, err = jwtauth.NewJWKSFromFile(jwksFile)
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if config.JWKS == nil && config.HMACSecret == "" {
+		if gin.Mode() == gin.ReleaseMode {
+			return config, jwtauth.ErrNoKeyConfigured
+		}
+		zap.L().Warn("No JWT key configured, using the development secret")
+		config.HMACSecret = "your-secret-key"
+	}
+
+	return config, nil
+}
+
+// newRevocationList shares revocations through Redis when REDIS_URL is set,
+// otherwise they are kept in memory and lost on restart.
+func newRevocationList(maxTokenTTL time.Duration) (jwtauth.RevocationList, error) {
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		zap.L().Warn("REDIS_URL is not set, revoked tokens are kept in memory")
+		return jwtauth.NewMemoryRevocationList(), nil
+	}
+	return jwtauth.NewRedisRevocationList(redisURL, maxTokenTTL)
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Number of tokens cached when VerifierConfig.CacheSize is not set.
+const defaultCacheSize = 10000
+
+// tokenCache remembers the claims of tokens whose signature and claims were
+// verified, keyed by the SHA-256 of the token so raw tokens are not kept in
+// memory. Revocation is not cached.
+type tokenCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[[sha256.Size]byte]cacheEntry
+}
+
+type cacheEntry struct {
+	claims    *Claims
+	expiresAt time.Time
+}
+
+func newTokenCache(ttl time.Duration, size int) *tokenCache {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+
+	return &tokenCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[[sha256.Size]byte]cacheEntry),
+	}
+}
+
+func (c *tokenCache) get(tokenString string) (*Claims, bool) {
+	key := sha256.Sum256([]byte(tokenString))
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.claims, true
+}
+
+// put caches claims for the cache TTL, or until the token expires if that is
+// sooner.
+func (c *tokenCache) put(tokenString string, claims *Claims) {
+	expiresAt := time.Now().Add(c.ttl)
+	if claims.ExpiresAt != nil && claims.ExpiresAt.Before(expiresAt) {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[sha256.Sum256([]byte(tokenString))] = cacheEntry{
+		claims:    claims,
+		expiresAt: expiresAt,
+	}
+}
+
+// evict drops expired entries, or an arbitrary one if none has expired.
+func (c *tokenCache) evict() {
+	now := time.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+
+	if len(c.entries) >= c.size {
+		for key := range c.entries {
+			delete(c.entries, key)
+			break
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"crypto/rand"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Value of the token_use claim of refresh tokens.
+const tokenUseRefresh = "refresh"
+
+type IssuerConfig struct {
+	// Secret signs the HS256 tokens, shared with the auth service.
+	Secret string
+
+	// Issuer and Audience are set as the iss and aud claims.
+	Issuer   string
+	Audience string
+
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	// MaxSessionAge, if set, caps how long after login tokens can be
+	// refreshed. Refreshed tokens keep the roles of the login, so this bounds
+	// how long a demoted user keeps them.
+	MaxSessionAge time.Duration
+}
+
+// TokenIssuer signs the refresh tokens handed out at login and the token
+// pairs returned when they are exchanged.
+type TokenIssuer struct {
+	config IssuerConfig
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"`
+}
+
+func NewTokenIssuer(config IssuerConfig) *TokenIssuer {
+	return &TokenIssuer{config: config}
+}
+
+// RefreshTokenTTL is the lifetime of issued refresh tokens.
+func (i *TokenIssuer) RefreshTokenTTL() time.Duration {
+	return i.config.RefreshTokenTTL
+}
+
+// IssueRefreshToken returns a refresh token for the identity in claims.
+func (i *TokenIssuer) IssueRefreshToken(claims *Claims) (string, error) {
+	return i.sign(claims, tokenUseRefresh, i.config.RefreshTokenTTL)
+}
+
+// IssuePair returns a new access and refresh token for the identity in
+// claims.
+func (i *TokenIssuer) IssuePair(claims *Claims) (*TokenPair, error) {
+	accessToken, err := i.sign(claims, "", i.config.AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := i.IssueRefreshToken(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(i.config.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (i *TokenIssuer) sign(identity *Claims, tokenUse string, ttl time.Duration) (string, error) {
+	now := time.Now()
+
+	authTime := now
+	if identity.AuthTime != nil {
+		authTime = identity.AuthTime.Time
+	}
+
+	expiresAt := now.Add(ttl)
+	if i.config.MaxSessionAge > 0 {
+		if sessionEnd := authTime.Add(i.config.MaxSessionAge); sessionEnd.Before(expiresAt) {
+			expiresAt = sessionEnd
+		}
+	}
+
+	claims := &Claims{
+		UserID:      identity.UserID,
+		Email:       identity.Email,
+		Roles:       identity.Roles,
+		Permissions: identity.Permissions,
+		TokenUse:    tokenUse,
+		AuthTime:    jwt.NewNumericDate(authTime),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        rand.Text(),
+			Issuer:    i.config.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	if i.config.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{i.config.Audience}
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(i.config.Secret))
+}
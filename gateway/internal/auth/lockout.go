@@ -0,0 +1,324 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LockoutPolicy slows down and then stops repeated failed logins.
+type LockoutPolicy struct {
+	// FreeAttempts failures are allowed without delay. After that the wait
+	// before the next attempt starts at BaseDelay and doubles with every
+	// failure, up to MaxDelay.
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+
+	// After LockoutThreshold failures attempts are refused for
+	// LockoutDuration.
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+
+	// Failures are forgotten Window after the last one.
+	Window time.Duration
+}
+
+// wait returns how long after the last failure the next attempt is refused,
+// and whether that is a lockout rather than a backoff delay.
+func (p LockoutPolicy) wait(failures int) (time.Duration, bool) {
+	if failures >= p.LockoutThreshold {
+		return p.LockoutDuration, true
+	}
+	if failures < p.FreeAttempts {
+		return 0, false
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay), false
+}
+
+// block returns the block on an attempt given the state of its key, or nil
+// if the attempt may proceed. Attempts in progress are refused only once they
+// could reach the lockout threshold together with the failures so far.
+func (p LockoutPolicy) block(scope string, state FailureState) *LoginBlock {
+	wait, locked := p.wait(state.Failures)
+	if retryAfter := time.Until(state.Last.Add(wait)); retryAfter > 0 {
+		return &LoginBlock{Scope: scope, Locked: locked, RetryAfter: retryAfter}
+	}
+	if state.Failures+state.Pending >= p.LockoutThreshold {
+		// The attempts in progress end within seconds
+		return &LoginBlock{Scope: scope, RetryAfter: max(p.BaseDelay, time.Second)}
+	}
+	return nil
+}
+
+// ttl is how long the failures of a key must be kept.
+func (p LockoutPolicy) ttl() time.Duration {
+	return max(p.Window, p.LockoutDuration)
+}
+
+// FailureState is what a FailureStore knows about a key.
+type FailureState struct {
+	// Failures is the number of failed logins and Last the time of the
+	// latest one.
+	Failures int
+	Last     time.Time
+
+	// Pending is the number of attempts in progress.
+	Pending int
+}
+
+// FailureStore counts failed logins and the attempts in progress by key.
+type FailureStore interface {
+	// Begin starts an attempt for key unless allow refuses the state of the
+	// key. Checking and starting happen atomically, and allow may be called
+	// more than once. It reports whether the attempt was started.
+	Begin(ctx context.Context, key string, ttl time.Duration, allow func(state FailureState) bool) (bool, error)
+
+	// Fail ends an attempt started by Begin as a failed login, kept for ttl,
+	// and returns the number of failures including it.
+	Fail(ctx context.Context, key string, ttl time.Duration) (int, error)
+
+	// Release ends an attempt started by Begin without recording a failure,
+	// leaving the key as it was before the attempt.
+	Release(ctx context.Context, key string) error
+
+	// Reset forgets the failures of key.
+	Reset(ctx context.Context, key string) error
+
+	// Close releases the connections of the store.
+	Close() error
+}
+
+// LoginBlock describes why login attempts are refused.
+type LoginBlock struct {
+	// Scope is "email" or "ip".
+	Scope string
+
+	// Locked is set for a lockout, otherwise the attempt is refused by the
+	// backoff delay or by too many attempts in progress.
+	Locked bool
+
+	// RetryAfter is the wait until attempts are accepted again.
+	RetryAfter time.Duration
+}
+
+// LoginGuard applies lockout policies to failed logins, per email and per
+// client IP. Counting by email stops guessing the password of one account,
+// counting by IP stops trying many accounts from one address.
+type LoginGuard struct {
+	store   FailureStore
+	byEmail LockoutPolicy
+	byIP    LockoutPolicy
+}
+
+func NewLoginGuard(store FailureStore, byEmail, byIP LockoutPolicy) *LoginGuard {
+	return &LoginGuard{
+		store:   store,
+		byEmail: byEmail,
+		byIP:    byIP,
+	}
+}
+
+// LoginAttempt is a login attempt started by Begin. It must be ended by
+// Fail, Succeed or Cancel.
+type LoginAttempt struct {
+	guard  *LoginGuard
+	scopes []lockoutScope
+}
+
+// Begin starts a login attempt before the credentials are checked. Attempts
+// in progress count towards the lockout threshold but not towards the backoff
+// delay, so a burst of concurrent attempts cannot pass the threshold before
+// any of them fails, while only rejected credentials slow the next attempt
+// down. If the attempt is refused, the block is returned instead.
+func (g *LoginGuard) Begin(ctx context.Context, email, ip string) (*LoginAttempt, *LoginBlock, error) {
+	attempt := &LoginAttempt{guard: g}
+	for _, scope := range g.scopes(email, ip) {
+		var block *LoginBlock
+		started, err := g.store.Begin(ctx, scope.key, scope.policy.ttl(), func(state FailureState) bool {
+			block = scope.policy.block(scope.name, state)
+			return block == nil
+		})
+		if err != nil || !started {
+			return nil, block, errors.Join(err, attempt.release(ctx, attempt.scopes))
+		}
+
+		attempt.scopes = append(attempt.scopes, scope)
+	}
+	return attempt, nil, nil
+}
+
+// Fail records the failure of a rejected login and returns the lockouts it
+// started.
+func (a *LoginAttempt) Fail(ctx context.Context) ([]LoginBlock, error) {
+	var lockouts []LoginBlock
+	var errs []error
+	for _, scope := range a.scopes {
+		failures, err := a.guard.store.Fail(ctx, scope.key, scope.policy.ttl())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if failures == scope.policy.LockoutThreshold {
+			lockouts = append(lockouts, LoginBlock{
+				Scope:      scope.name,
+				Locked:     true,
+				RetryAfter: scope.policy.LockoutDuration,
+			})
+		}
+	}
+	return lockouts, errors.Join(errs...)
+}
+
+// Succeed forgets the failures of the email after a successful login. The
+// failures of the IP are kept, so one valid account cannot clear them.
+func (a *LoginAttempt) Succeed(ctx context.Context) error {
+	var errs []error
+	for _, scope := range a.scopes {
+		if scope.name == "email" {
+			errs = append(errs, a.guard.store.Reset(ctx, scope.key))
+		} else {
+			errs = append(errs, a.guard.store.Release(ctx, scope.key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cancel ends the attempt without recording a failure when the credentials
+// could not be checked, e.g. because the auth service was unavailable.
+func (a *LoginAttempt) Cancel(ctx context.Context) error {
+	return a.release(ctx, a.scopes)
+}
+
+func (a *LoginAttempt) release(ctx context.Context, scopes []lockoutScope) error {
+	var errs []error
+	for _, scope := range scopes {
+		errs = append(errs, a.guard.store.Release(ctx, scope.key))
+	}
+	return errors.Join(errs...)
+}
+
+type lockoutScope struct {
+	name   string
+	key    string
+	policy LockoutPolicy
+}
+
+func (g *LoginGuard) scopes(email, ip string) []lockoutScope {
+	return []lockoutScope{
+		{name: "email", key: emailKey(email), policy: g.byEmail},
+		{name: "ip", key: "ip:" + ip, policy: g.byIP},
+	}
+}
+
+// emailKey hashes the normalized email so addresses are not stored in keys.
+func emailKey(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return "email:" + hex.EncodeToString(sum[:])
+}
+
+// Interval between sweeps of expired in-memory failure records.
+const failureSweepInterval = time.Minute
+
+// MemoryFailureStore keeps failed logins in process memory. It suits tests
+// and single-instance deployments.
+type MemoryFailureStore struct {
+	mutex     sync.Mutex
+	records   map[string]*failureRecord
+	lastSweep time.Time
+}
+
+type failureRecord struct {
+	FailureState
+	expiresAt time.Time
+}
+
+func NewMemoryFailureStore() *MemoryFailureStore {
+	return &MemoryFailureStore{
+		records:   make(map[string]*failureRecord),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryFailureStore) Begin(ctx context.Context, key string, ttl time.Duration, allow func(state FailureState) bool) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= failureSweepInterval {
+		for k, record := range s.records {
+			if now.After(record.expiresAt) {
+				delete(s.records, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	record, ok := s.records[key]
+	if !ok || now.After(record.expiresAt) {
+		record = &failureRecord{}
+	}
+	if !allow(record.FailureState) {
+		return false, nil
+	}
+
+	if record.Failures == 0 && record.Pending == 0 {
+		record.expiresAt = now.Add(ttl)
+	}
+	record.Pending++
+	s.records[key] = record
+	return true, nil
+}
+
+func (s *MemoryFailureStore) Fail(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	record, ok := s.records[key]
+	if !ok {
+		record = &failureRecord{}
+		s.records[key] = record
+	}
+	record.Pending = max(record.Pending-1, 0)
+	record.Failures++
+	record.Last = now
+	record.expiresAt = now.Add(ttl)
+	return record.Failures, nil
+}
+
+func (s *MemoryFailureStore) Release(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+	record.Pending = max(record.Pending-1, 0)
+	if record.Pending == 0 && record.Failures == 0 {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *MemoryFailureStore) Reset(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *MemoryFailureStore) Close() error {
+	return nil
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// Prefix of the failed login keys.
+	loginFailuresKeyPrefix = "auth:login:failures:"
+
+	// Times starting an attempt is retried when another one changes the key
+	// between its read and its write.
+	maxBeginRetries = 10
+)
+
+// failScript ends an attempt of the key in KEYS[1] as a failure at ARGV[1]
+// (Unix milliseconds), keeps the key for ARGV[2] milliseconds and returns the
+// number of failures.
+var failScript = redis.NewScript(`
+if (tonumber(redis.call('HGET', KEYS[1], 'pending')) or 0) > 0 then
+	redis.call('HINCRBY', KEYS[1], 'pending', -1)
+end
+
+local failures = redis.call('HINCRBY', KEYS[1], 'failures', 1)
+redis.call('HSET', KEYS[1], 'last', ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return failures
+`)
+
+// releaseScript ends an attempt of the key in KEYS[1] without a failure and
+// deletes the key when nothing is left in it.
+var releaseScript = redis.NewScript(`
+local pending = tonumber(redis.call('HGET', KEYS[1], 'pending')) or 0
+if pending > 0 then
+	pending = redis.call('HINCRBY', KEYS[1], 'pending', -1)
+end
+
+local failures = tonumber(redis.call('HGET', KEYS[1], 'failures')) or 0
+if pending <= 0 and failures <= 0 then
+	redis.call('DEL', KEYS[1])
+end
+return pending
+`)
+
+// RedisFailureStore shares failed login counts between gateway replicas
+// through Redis.
+type RedisFailureStore struct {
+	client *redis.Client
+}
+
+func NewRedisFailureStore(redisURL string) (*RedisFailureStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	zap.L().Info("Connected to Redis login failure store")
+
+	return &RedisFailureStore{client: client}, nil
+}
+
+// Begin reads the key and starts the attempt in a WATCH transaction, which
+// is retried when another gateway changes the key in between.
+func (s *RedisFailureStore) Begin(ctx context.Context, key string, ttl time.Duration, allow func(state FailureState) bool) (bool, error) {
+	key = loginFailuresKeyPrefix + key
+
+	for range maxBeginRetries {
+		var started bool
+		err := s.client.Watch(ctx, func(tx *redis.Tx) error {
+			state, err := readFailureState(ctx, tx, key)
+			if err != nil {
+				return err
+			}
+			if !allow(state) {
+				return nil
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.HIncrBy(ctx, key, "pending", 1)
+				if state.Failures == 0 && state.Pending == 0 {
+					pipe.PExpire(ctx, key, ttl)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			started = true
+			return nil
+		}, key)
+
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to start login attempt: %w", err)
+		}
+		return started, nil
+	}
+
+	return false, errors.New("failed to start login attempt: too many concurrent attempts")
+}
+
+func (s *RedisFailureStore) Fail(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	keys := []string{loginFailuresKeyPrefix + key}
+	failures, err := failScript.Run(ctx, s.client, keys, time.Now().UnixMilli(), ttl.Milliseconds()).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return failures, nil
+}
+
+func (s *RedisFailureStore) Release(ctx context.Context, key string) error {
+	if err := releaseScript.Run(ctx, s.client, []string{loginFailuresKeyPrefix + key}).Err(); err != nil {
+		return fmt.Errorf("failed to release login attempt: %w", err)
+	}
+	return nil
+}
+
+// readFailureState returns the failures, the time of the last one and the
+// attempts in progress recorded in key.
+func readFailureState(ctx context.Context, client redis.Cmdable, key string) (FailureState, error) {
+	values, err := client.HMGet(ctx, key, "failures", "last", "pending").Result()
+	if err != nil {
+		return FailureState{}, fmt.Errorf("failed to read login failures: %w", err)
+	}
+
+	var state FailureState
+	if value, _ := values[0].(string); value != "" {
+		if state.Failures, err = strconv.Atoi(value); err != nil {
+			return FailureState{}, fmt.Errorf("invalid login failure count %q", value)
+		}
+	}
+	if value, _ := values[1].(string); value != "" {
+		last, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return FailureState{}, fmt.Errorf("invalid login failure time %q", value)
+		}
+		state.Last = time.UnixMilli(last)
+	}
+	if value, _ := values[2].(string); value != "" {
+		if state.Pending, err = strconv.Atoi(value); err != nil {
+			return FailureState{}, fmt.Errorf("invalid pending login count %q", value)
+		}
+	}
+
+	return state, nil
+}
+
+func (s *RedisFailureStore) Reset(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, loginFailuresKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisFailureStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLoginGuard(t *testing.T) {
+	// Each free attempt is followed by a long delay, so tests can tell
+	// whether an attempt was delayed
+	delayed := LockoutPolicy{
+		FreeAttempts:     2,
+		BaseDelay:        time.Hour,
+		MaxDelay:         time.Hour,
+		LockoutThreshold: 10,
+		LockoutDuration:  time.Hour,
+		Window:           time.Hour,
+	}
+	undelayed := LockoutPolicy{
+		FreeAttempts:     10,
+		LockoutThreshold: 3,
+		LockoutDuration:  time.Hour,
+		Window:           time.Hour,
+	}
+
+	// Steps start an attempt, or end the oldest one in progress
+	type step struct {
+		op string // begin, fail, cancel or succeed
+
+		// For begin, whether the attempt is refused and the refusal is a
+		// lockout; for fail, whether a lockout starts
+		wantBlock  bool
+		wantLocked bool
+	}
+
+	tests := []struct {
+		name      string
+		policy    LockoutPolicy
+		steps     []step
+		wantState FailureState
+	}{
+		{
+			name:   "free attempts",
+			policy: delayed,
+			steps: []step{
+				{op: "begin"}, {op: "fail"},
+				{op: "begin"}, {op: "fail"},
+				{op: "begin", wantBlock: true},
+			},
+			wantState: FailureState{Failures: 2},
+		},
+		{
+			name:   "attempts in progress do not delay others",
+			policy: delayed,
+			steps: []step{
+				{op: "begin"}, {op: "begin"}, {op: "begin"}, {op: "begin"},
+			},
+			wantState: FailureState{Pending: 4},
+		},
+		{
+			name:   "attempts in progress count towards the lockout threshold",
+			policy: undelayed,
+			steps: []step{
+				{op: "begin"}, {op: "fail"},
+				{op: "begin"}, {op: "begin"},
+				{op: "begin", wantBlock: true},
+			},
+			wantState: FailureState{Failures: 1, Pending: 2},
+		},
+		{
+			name:   "lockout at the threshold",
+			policy: undelayed,
+			steps: []step{
+				{op: "begin"}, {op: "fail"},
+				{op: "begin"}, {op: "fail"},
+				{op: "begin"}, {op: "fail", wantLocked: true},
+				{op: "begin", wantBlock: true, wantLocked: true},
+			},
+			wantState: FailureState{Failures: 3},
+		},
+		{
+			name:   "cancelled attempts are not counted",
+			policy: delayed,
+			steps: []step{
+				{op: "begin"}, {op: "cancel"},
+				{op: "begin"}, {op: "cancel"},
+				{op: "begin"}, {op: "cancel"},
+				{op: "begin"}, {op: "fail"},
+			},
+			wantState: FailureState{Failures: 1},
+		},
+		{
+			name:   "success forgets the failures",
+			policy: undelayed,
+			steps: []step{
+				{op: "begin"}, {op: "fail"},
+				{op: "begin"}, {op: "succeed"},
+			},
+			wantState: FailureState{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewMemoryFailureStore()
+			// The IP policy never gets in the way
+			guard := NewLoginGuard(store, tt.policy, LockoutPolicy{FreeAttempts: 100, LockoutThreshold: 100, Window: time.Hour})
+
+			var attempts []*LoginAttempt
+			for i, step := range tt.steps {
+				switch step.op {
+				case "begin":
+					attempt, block, err := guard.Begin(ctx, "alice@example.com", "192.0.2.1")
+					if err != nil {
+						t.Fatalf("step %d: Begin: %v", i, err)
+					}
+					if (block != nil) != step.wantBlock {
+						t.Fatalf("step %d: Begin block = %+v, want blocked = %v", i, block, step.wantBlock)
+					}
+					if block != nil {
+						if block.Scope != "email" || block.Locked != step.wantLocked || block.RetryAfter <= 0 {
+							t.Errorf("step %d: block = %+v, want an email block with locked = %v", i, block, step.wantLocked)
+						}
+						continue
+					}
+					attempts = append(attempts, attempt)
+
+				case "fail", "cancel", "succeed":
+					attempt := attempts[0]
+					attempts = attempts[1:]
+
+					var err error
+					switch step.op {
+					case "fail":
+						var lockouts []LoginBlock
+						lockouts, err = attempt.Fail(ctx)
+						if locked := len(lockouts) > 0; locked != step.wantLocked {
+							t.Errorf("step %d: Fail lockouts = %+v, want locked = %v", i, lockouts, step.wantLocked)
+						}
+					case "cancel":
+						err = attempt.Cancel(ctx)
+					case "succeed":
+						err = attempt.Succeed(ctx)
+					}
+					if err != nil {
+						t.Fatalf("step %d: %s: %v", i, step.op, err)
+					}
+				}
+			}
+
+			var got FailureState
+			if record, ok := store.records[emailKey("alice@example.com")]; ok {
+				got = record.FailureState
+			}
+			if got.Failures != tt.wantState.Failures || got.Pending != tt.wantState.Pending {
+				t.Errorf("state = %+v, want %+v", got, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestLoginAttemptCancelRestoresState(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryFailureStore()
+	policy := LockoutPolicy{FreeAttempts: 10, LockoutThreshold: 10, Window: time.Hour}
+	guard := NewLoginGuard(store, policy, policy)
+
+	attempt, _, err := guard.Begin(ctx, "alice@example.com", "192.0.2.1")
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if _, err := attempt.Fail(ctx); err != nil {
+		t.Fatalf("Fail: %v", err)
+	}
+
+	before := make(map[string]FailureState)
+	for key, record := range store.records {
+		before[key] = record.FailureState
+	}
+
+	time.Sleep(time.Millisecond)
+	attempt, _, err = guard.Begin(ctx, "alice@example.com", "192.0.2.1")
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := attempt.Cancel(ctx); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+
+	for key, want := range before {
+		if got := store.records[key].FailureState; got != want {
+			t.Errorf("%s: state after Cancel = %+v, want %+v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"api-gateway/shared/jwtauth"
+)
+
+var ErrWrongTokenUse = errors.New("token cannot be used here")
+
+// Claims are the JWT claims the gateway relies on.
+type Claims = jwtauth.Claims
+
+type VerifierConfig struct {
+	// Keys and expected issuer and audience of the tokens.
+	jwtauth.Config
+
+	// Revocations, if set, rejects revoked tokens.
+	Revocations jwtauth.RevocationList
+
+	// CacheTTL, if set, caches the claims of verified tokens for up to that
+	// long so repeated requests skip the signature check. Revocation is
+	// checked on every call. CacheSize bounds the number of cached tokens.
+	CacheTTL  time.Duration
+	CacheSize int
+}
+
+// Verifier checks the signature, claims and revocation of access and refresh
+// tokens.
+type Verifier struct {
+	parser      *jwtauth.Parser
+	revocations jwtauth.RevocationList
+	cache       *tokenCache
+}
+
+func NewVerifier(config VerifierConfig) (*Verifier, error) {
+	parser, err := jwtauth.NewParser(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Verifier{
+		parser:      parser,
+		revocations: config.Revocations,
+	}
+	if config.CacheTTL > 0 {
+		v.cache = newTokenCache(config.CacheTTL, config.CacheSize)
+	}
+
+	return v, nil
+}
+
+// Verify returns the claims of a valid, unrevoked access token.
+func (v *Verifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	return v.verify(ctx, tokenString, "")
+}
+
+// VerifyRefresh returns the claims of a valid, unrevoked refresh token.
+func (v *Verifier) VerifyRefresh(ctx context.Context, tokenString string) (*Claims, error) {
+	return v.verify(ctx, tokenString, tokenUseRefresh)
+}
+
+func (v *Verifier) verify(ctx context.Context, tokenString, tokenUse string) (*Claims, error) {
+	claims, err := v.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenUse != tokenUse {
+		return nil, ErrWrongTokenUse
+	}
+
+	if v.revocations != nil {
+		revoked, err := v.revocations.IsRevoked(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, jwtauth.ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+// parse checks the signature and registered claims of a token, or returns
+// the cached claims of a token that passed before.
+func (v *Verifier) parse(tokenString string) (*Claims, error) {
+	if v.cache != nil {
+		if claims, ok := v.cache.get(tokenString); ok {
+			return claims, nil
+		}
+	}
+
+	claims, err := v.parser.Parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.cache != nil {
+		v.cache.put(tokenString, claims)
+	}
+	return claims, nil
+}
@@ -0,0 +1,180 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/balancer/leastrequest"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health" // registers the client health check
+	"google.golang.org/grpc/status"
+)
+
+// Reconnection backoff of backends. The delay is capped well below the gRPC
+// default of two minutes so a recovered backend is picked up quickly.
+var backendConnectParams = grpc.ConnectParams{
+	Backoff: backoff.Config{
+		BaseDelay:  time.Second,
+		Multiplier: 1.6,
+		Jitter:     0.2,
+		MaxDelay:   10 * time.Second,
+	},
+	MinConnectTimeout: 5 * time.Second,
+}
+
+// Load balancing policies of backends with several addresses.
+const (
+	BalancerRoundRobin   = "round_robin"
+	BalancerLeastRequest = "least_request"
+	BalancerPickFirst    = "pick_first"
+)
+
+// Names of the gRPC balancers implementing the policies.
+var balancerNames = map[string]string{
+	BalancerRoundRobin:   roundrobin.Name,
+	BalancerLeastRequest: leastrequest.Name,
+	BalancerPickFirst:    "pick_first",
+}
+
+// serviceConfig returns the gRPC service config selecting the balancer and,
+// if enabled, grpc.health.v1 health checks of every address. Addresses that
+// report NOT_SERVING get no calls; servers without the health service are
+// treated as serving.
+func serviceConfig(balancer string, healthCheck bool) (string, error) {
+	name, ok := balancerNames[balancer]
+	if !ok {
+		return "", fmt.Errorf("unknown load balancing policy %q", balancer)
+	}
+
+	config := map[string]any{
+		"loadBalancingConfig": []map[string]any{{name: struct{}{}}},
+	}
+	if healthCheck {
+		config["healthCheckConfig"] = map[string]string{"serviceName": ""}
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// backend is the connection to one service. Connections are established in
+// the background, so the gateway starts while a backend is down and calls
+// to it fail with Unavailable until it is reachable.
+type backend struct {
+	name    string
+	conn    *grpc.ClientConn
+	breaker *CircuitBreaker
+}
+
+// BackendStatus is a snapshot of a backend connection.
+type BackendStatus struct {
+	Backend string `json:"backend"`
+	State   string `json:"state"`
+	Ready   bool   `json:"ready"`
+}
+
+// newBackend creates the connection to a backend with readiness checks,
+// retries and a circuit breaker of its own, and starts connecting. The
+// address may list several replicas, see backendTarget.
+func newBackend(name, address string, config GRPCConfig) (*backend, error) {
+	b := &backend{
+		name:    name,
+		breaker: NewCircuitBreaker(name, *config.Breaker),
+	}
+
+	serviceConfig, err := serviceConfig(config.Balancer, !config.DisableHealthCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(backendTarget(address),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithConnectParams(backendConnectParams),
+		grpc.WithResolvers(staticResolverBuilder{}, fileResolverBuilder{}),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(
+			b.readinessInterceptor(),
+			retryInterceptor(*config.Retry),
+			b.breaker.UnaryClientInterceptor(),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+	b.conn = conn
+
+	go b.watch()
+	return b, nil
+}
+
+// ready reports whether calls may be sent. Only a backend that failed to
+// connect is not ready; idle and connecting backends get the chance to
+// connect.
+func (b *backend) ready() bool {
+	state := b.conn.GetState()
+	return state != connectivity.TransientFailure && state != connectivity.Shutdown
+}
+
+func (b *backend) status() BackendStatus {
+	return BackendStatus{
+		Backend: b.name,
+		State:   b.conn.GetState().String(),
+		Ready:   b.ready(),
+	}
+}
+
+// readinessInterceptor fails calls to a backend that is down without
+// waiting for the call deadline or retrying.
+func (b *backend) readinessInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.ready() {
+			return status.Errorf(codes.Unavailable, "%s service is not ready", b.name)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// watch keeps the connection trying to connect and logs when the backend
+// becomes ready or unavailable, until the connection is closed.
+func (b *backend) watch() {
+	state := b.conn.GetState()
+	reported := state
+	for {
+		if state == connectivity.Idle {
+			b.conn.Connect()
+		}
+
+		if !b.conn.WaitForStateChange(context.Background(), state) {
+			return
+		}
+		state = b.conn.GetState()
+
+		switch state {
+		case connectivity.Ready:
+			if reported != connectivity.Ready {
+				zap.L().Info("gRPC backend ready", zap.String("backend", b.name))
+			}
+			reported = state
+		case connectivity.TransientFailure:
+			// Reconnection attempts pass through Connecting, so only
+			// the first failure after being ready is reported
+			if reported != connectivity.TransientFailure {
+				zap.L().Warn("gRPC backend unavailable", zap.String("backend", b.name))
+			}
+			reported = state
+		case connectivity.Shutdown:
+			return
+		}
+	}
+}
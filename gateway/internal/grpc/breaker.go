@@ -0,0 +1,289 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of buckets the breaker window is divided into.
+const breakerBuckets = 10
+
+// errCircuitOpen is returned without calling the backend while its breaker
+// is open. It maps to 503 like any unavailable backend.
+var errCircuitOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// BreakerPolicy decides when a backend's circuit breaker opens and closes.
+type BreakerPolicy struct {
+	// The breaker opens when at least MinRequests calls were made in the
+	// last Window and at least FailureRate of them failed.
+	Window      time.Duration
+	MinRequests int
+	FailureRate float64
+
+	// After OpenTimeout an open breaker lets HalfOpenRequests calls
+	// through. It closes if they all succeed and opens again otherwise.
+	OpenTimeout      time.Duration
+	HalfOpenRequests int
+}
+
+var DefaultBreakerPolicy = BreakerPolicy{
+	Window:           30 * time.Second,
+	MinRequests:      20,
+	FailureRate:      0.5,
+	OpenTimeout:      15 * time.Second,
+	HalfOpenRequests: 3,
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Outcome of a call as seen by the breaker.
+type callOutcome int
+
+const (
+	callSucceeded callOutcome = iota
+	callFailed
+
+	// callIgnored is a call canceled by the caller, which says nothing
+	// about the backend.
+	callIgnored
+)
+
+type breakerBucket struct {
+	start    time.Time
+	requests int
+	failures int
+}
+
+// CircuitBreaker fast-fails calls to a backend whose error rate crossed the
+// policy threshold, giving it time to recover. There is one breaker per
+// backend service, not per replica: failures of all replicas count together,
+// and an open breaker stops calls to every replica. Unhealthy replicas are
+// taken out of rotation by the grpc.health.v1 checks of the balancer, before
+// their errors can open the breaker.
+type CircuitBreaker struct {
+	backend string
+	policy  BreakerPolicy
+
+	mutex          sync.Mutex
+	state          breakerState
+	buckets        [breakerBuckets]breakerBucket
+	openedAt       time.Time
+	probes         int
+	probeSuccesses int
+
+	// generation changes with every state change. Calls are tagged with
+	// the generation they started in, and results of calls started in an
+	// earlier one are ignored.
+	generation uint64
+}
+
+// BreakerStatus is a snapshot of a circuit breaker.
+type BreakerStatus struct {
+	Backend     string     `json:"backend"`
+	State       string     `json:"state"`
+	Requests    int        `json:"requests"`
+	Failures    int        `json:"failures"`
+	FailureRate float64    `json:"failureRate"`
+	OpenedAt    *time.Time `json:"openedAt,omitempty"`
+}
+
+func NewCircuitBreaker(backend string, policy BreakerPolicy) *CircuitBreaker {
+	return &CircuitBreaker{
+		backend: backend,
+		policy:  policy,
+	}
+}
+
+// UnaryClientInterceptor guards the calls of a connection with the breaker.
+func (b *CircuitBreaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		generation, err := b.allow()
+		if err != nil {
+			return err
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		b.record(generation, outcomeOf(ctx, err))
+		return err
+	}
+}
+
+// Status returns the current state and the counts of the window.
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	requests, failures := b.counts(time.Now())
+
+	status := BreakerStatus{
+		Backend:  b.backend,
+		State:    b.state.String(),
+		Requests: requests,
+		Failures: failures,
+	}
+	if requests > 0 {
+		status.FailureRate = float64(failures) / float64(requests)
+	}
+	if b.state != breakerClosed {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+// allow reports whether a call may be made and returns the generation to
+// record its result with.
+func (b *CircuitBreaker) allow() (uint64, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == breakerOpen {
+		if time.Since(b.openedAt) < b.policy.OpenTimeout {
+			return 0, errCircuitOpen
+		}
+		b.setState(breakerHalfOpen)
+		b.probes = 0
+		b.probeSuccesses = 0
+		zap.L().Info("Circuit breaker half-open", zap.String("backend", b.backend))
+	}
+
+	if b.state == breakerHalfOpen {
+		if b.probes >= b.policy.HalfOpenRequests {
+			return 0, errCircuitOpen
+		}
+		b.probes++
+	}
+
+	return b.generation, nil
+}
+
+// record counts the outcome of a call allowed in the given generation.
+// Calls that started before the latest state change say nothing about it:
+// a call that was slow while the breaker was closed must not fail a probe.
+func (b *CircuitBreaker) record(generation uint64, outcome callOutcome) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	now := time.Now()
+
+	switch b.state {
+	case breakerClosed:
+		if outcome == callIgnored {
+			return
+		}
+
+		bucket := b.bucket(now)
+		bucket.requests++
+		if outcome == callFailed {
+			bucket.failures++
+		}
+
+		requests, failures := b.counts(now)
+		if requests >= b.policy.MinRequests && float64(failures) >= b.policy.FailureRate*float64(requests) {
+			b.open(now)
+			zap.L().Warn("Circuit breaker opened",
+				zap.String("backend", b.backend),
+				zap.Int("requests", requests),
+				zap.Int("failures", failures),
+			)
+		}
+
+	case breakerHalfOpen:
+		switch outcome {
+		case callIgnored:
+			// Let another call probe the backend instead
+			b.probes = max(b.probes-1, 0)
+		case callFailed:
+			b.open(now)
+			zap.L().Warn("Circuit breaker reopened", zap.String("backend", b.backend))
+		case callSucceeded:
+			b.probeSuccesses++
+			if b.probeSuccesses >= b.policy.HalfOpenRequests {
+				b.setState(breakerClosed)
+				b.buckets = [breakerBuckets]breakerBucket{}
+				zap.L().Info("Circuit breaker closed", zap.String("backend", b.backend))
+			}
+		}
+	}
+}
+
+func (b *CircuitBreaker) open(now time.Time) {
+	b.setState(breakerOpen)
+	b.openedAt = now
+}
+
+func (b *CircuitBreaker) setState(state breakerState) {
+	b.state = state
+	b.generation++
+}
+
+// bucket returns the bucket of the window covering now, clearing it if it
+// last held an older period.
+func (b *CircuitBreaker) bucket(now time.Time) *breakerBucket {
+	width := b.policy.Window / breakerBuckets
+	start := now.Truncate(width)
+	bucket := &b.buckets[(start.UnixNano()/int64(width))%breakerBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = breakerBucket{start: start}
+	}
+	return bucket
+}
+
+// counts sums the buckets within the window.
+func (b *CircuitBreaker) counts(now time.Time) (requests, failures int) {
+	for _, bucket := range b.buckets {
+		if now.Sub(bucket.start) < b.policy.Window {
+			requests += bucket.requests
+			failures += bucket.failures
+		}
+	}
+	return requests, failures
+}
+
+// outcomeOf classifies a call result. Only errors that point at an
+// unhealthy backend count as failures; business errors such as NotFound
+// mean the backend is working.
+func outcomeOf(ctx context.Context, err error) callOutcome {
+	if err == nil {
+		return callSucceeded
+	}
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return callIgnored
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.DataLoss:
+		return callFailed
+	case codes.Canceled:
+		return callIgnored
+	}
+	return callSucceeded
+}
@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	policy := BreakerPolicy{
+		Window:           time.Hour,
+		MinRequests:      2,
+		FailureRate:      0.5,
+		OpenTimeout:      20 * time.Millisecond,
+		HalfOpenRequests: 2,
+	}
+
+	// Steps make a call, start one, finish the oldest call started, or
+	// wait for the open timeout
+	type step struct {
+		op           string // call, start, finish or wait
+		outcome      callOutcome
+		wantRejected bool
+	}
+	ok := step{op: "call", outcome: callSucceeded}
+	fail := step{op: "call", outcome: callFailed}
+	wait := step{op: "wait"}
+	start := step{op: "start"}
+	rejected := step{op: "call", wantRejected: true}
+
+	tests := []struct {
+		name       string
+		steps      []step
+		wantState  breakerState
+		wantProbes int
+	}{
+		{
+			name:      "below the minimum requests",
+			steps:     []step{fail},
+			wantState: breakerClosed,
+		},
+		{
+			name:      "below the failure rate",
+			steps:     []step{ok, ok, fail},
+			wantState: breakerClosed,
+		},
+		{
+			name:      "opens at the failure rate",
+			steps:     []step{fail, fail, rejected},
+			wantState: breakerOpen,
+		},
+		{
+			name:      "canceled calls are not counted",
+			steps:     []step{fail, {op: "call", outcome: callIgnored}},
+			wantState: breakerClosed,
+		},
+		{
+			name:      "probes close it",
+			steps:     []step{fail, fail, wait, ok, ok},
+			wantState: breakerClosed,
+		},
+		{
+			name:      "failed probe reopens it",
+			steps:     []step{fail, fail, wait, ok, fail, rejected},
+			wantState: breakerOpen,
+		},
+		{
+			name:       "half-open limits the probes",
+			steps:      []step{fail, fail, wait, start, start, rejected},
+			wantState:  breakerHalfOpen,
+			wantProbes: 2,
+		},
+		{
+			name:       "canceled probe lets another call through",
+			steps:      []step{fail, fail, wait, start, start, {op: "finish", outcome: callIgnored}, start},
+			wantState:  breakerHalfOpen,
+			wantProbes: 2,
+		},
+		{
+			name: "failure of a call started while closed does not reopen it",
+			steps: []step{
+				start, fail, fail, wait, ok,
+				{op: "finish", outcome: callFailed},
+				ok,
+			},
+			wantState: breakerClosed,
+		},
+		{
+			name: "cancel of a call started while closed does not free a probe",
+			steps: []step{
+				start, fail, fail, wait, start, start,
+				{op: "finish", outcome: callIgnored},
+				rejected,
+			},
+			wantState:  breakerHalfOpen,
+			wantProbes: 2,
+		},
+		{
+			name: "success of a call started while open does not close it",
+			steps: []step{
+				fail, fail, wait, start, fail,
+				{op: "finish", outcome: callSucceeded},
+				rejected,
+			},
+			wantState: breakerOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewCircuitBreaker("test", policy)
+
+			var started []uint64
+			for i, step := range tt.steps {
+				switch step.op {
+				case "call", "start":
+					generation, err := b.allow()
+					if rejected := errors.Is(err, errCircuitOpen); rejected != step.wantRejected {
+						t.Fatalf("step %d: allow = %v, want rejected = %v", i, err, step.wantRejected)
+					}
+					if err != nil {
+						continue
+					}
+					if step.op == "start" {
+						started = append(started, generation)
+					} else {
+						b.record(generation, step.outcome)
+					}
+				case "finish":
+					b.record(started[0], step.outcome)
+					started = started[1:]
+				case "wait":
+					time.Sleep(policy.OpenTimeout + 5*time.Millisecond)
+				}
+			}
+
+			if b.state != tt.wantState {
+				t.Errorf("state = %v, want %v", b.state, tt.wantState)
+			}
+			if b.state == breakerHalfOpen && b.probes != tt.wantProbes {
+				t.Errorf("probes = %d, want %d", b.probes, tt.wantProbes)
+			}
+		})
+	}
+}
+
+func TestOutcomeOf(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want callOutcome
+	}{
+		{name: "success", ctx: context.Background(), want: callSucceeded},
+		{name: "unavailable", ctx: context.Background(), err: status.Error(codes.Unavailable, ""), want: callFailed},
+		{name: "deadline exceeded", ctx: context.Background(), err: status.Error(codes.DeadlineExceeded, ""), want: callFailed},
+		{name: "internal", ctx: context.Background(), err: status.Error(codes.Internal, ""), want: callFailed},
+		{name: "not found", ctx: context.Background(), err: status.Error(codes.NotFound, ""), want: callSucceeded},
+		{name: "canceled", ctx: context.Background(), err: status.Error(codes.Canceled, ""), want: callIgnored},
+		{name: "caller gone", ctx: canceled, err: status.Error(codes.Unavailable, ""), want: callIgnored},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := outcomeOf(tt.ctx, tt.err); got != tt.want {
+				t.Errorf("outcomeOf = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
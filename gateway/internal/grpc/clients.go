@@ -2,12 +2,13 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	auditpb "api-gateway/internal/grpc/audit/proto"
 	authpb "api-gateway/internal/grpc/auth/proto"
@@ -15,6 +16,7 @@ import (
 	disputepb "api-gateway/internal/grpc/dispute/proto"
 	notificationpb "api-gateway/internal/grpc/notification/proto"
 	paymentpb "api-gateway/internal/grpc/payment/proto"
+	sharedMiddleware "api-gateway/shared/middleware"
 )
 
 type GRPCClients struct {
@@ -24,7 +26,8 @@ type GRPCClients struct {
 	DisputeClient      disputepb.DisputeServiceClient
 	NotificationClient notificationpb.NotificationServiceClient
 	AuditClient        auditpb.AuditServiceClient
-	connections        []*grpc.ClientConn
+	backends           []*backend
+	serviceBackends    map[string]*backend
 }
 
 type GRPCConfig struct {
@@ -34,87 +37,167 @@ type GRPCConfig struct {
 	DisputeServiceAddr      string
 	NotificationServiceAddr string
 	AuditServiceAddr        string
+
+	// Retry and Breaker apply to the calls of every backend, each backend
+	// having its own breaker. They default to DefaultRetryPolicy and
+	// DefaultBreakerPolicy.
+	Retry   *RetryPolicy
+	Breaker *BreakerPolicy
+
+	// Balancer spreads calls over the addresses of a backend. Defaults to
+	// BalancerRoundRobin.
+	Balancer string
+
+	// DisableHealthCheck turns off grpc.health.v1 checks of backend
+	// addresses.
+	DisableHealthCheck bool
 }
 
 func NewGRPCClients(config GRPCConfig) (*GRPCClients, error) {
 	clients := &GRPCClients{
-		connections: make([]*grpc.ClientConn, 0),
+		backends:        make([]*backend, 0),
+		serviceBackends: make(map[string]*backend),
+	}
+
+	if config.Retry == nil {
+		config.Retry = &DefaultRetryPolicy
+	}
+	if config.Breaker == nil {
+		config.Breaker = &DefaultBreakerPolicy
+	}
+	if config.Balancer == "" {
+		config.Balancer = BalancerRoundRobin
 	}
 
 	// Create auth service client
-	authConn, err := createConnection(config.AuthServiceAddr)
+	authBackend, err := newBackend("auth", config.AuthServiceAddr, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
+		return nil, fmt.Errorf("failed to create auth service client: %w", err)
 	}
-	clients.AuthClient = authpb.NewAuthServiceClient(authConn)
-	clients.connections = append(clients.connections, authConn)
+	clients.AuthClient = authpb.NewAuthServiceClient(authBackend.conn)
+	clients.addBackend(authpb.AuthService_ServiceDesc.ServiceName, authBackend)
 
 	// Create contract service client
-	contractConn, err := createConnection(config.ContractServiceAddr)
+	contractBackend, err := newBackend("contract", config.ContractServiceAddr, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to contract service: %w", err)
+		return nil, fmt.Errorf("failed to create contract service client: %w", err)
 	}
-	clients.ContractClient = contractpb.NewContractServiceClient(contractConn)
-	clients.connections = append(clients.connections, contractConn)
+	clients.ContractClient = contractpb.NewContractServiceClient(contractBackend.conn)
+	clients.addBackend(contractpb.ContractService_ServiceDesc.ServiceName, contractBackend)
 
 	// Create payment service client
-	paymentConn, err := createConnection(config.PaymentServiceAddr)
+	paymentBackend, err := newBackend("payment", config.PaymentServiceAddr, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to payment service: %w", err)
+		return nil, fmt.Errorf("failed to create payment service client: %w", err)
 	}
-	clients.PaymentClient = paymentpb.NewPaymentServiceClient(paymentConn)
-	clients.connections = append(clients.connections, paymentConn)
+	clients.PaymentClient = paymentpb.NewPaymentServiceClient(paymentBackend.conn)
+	clients.addBackend(paymentpb.PaymentService_ServiceDesc.ServiceName, paymentBackend)
 
 	// Create dispute service client
-	disputeConn, err := createConnection(config.DisputeServiceAddr)
+	disputeBackend, err := newBackend("dispute", config.DisputeServiceAddr, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to dispute service: %w", err)
+		return nil, fmt.Errorf("failed to create dispute service client: %w", err)
 	}
-	clients.DisputeClient = disputepb.NewDisputeServiceClient(disputeConn)
-	clients.connections = append(clients.connections, disputeConn)
+	clients.DisputeClient = disputepb.NewDisputeServiceClient(disputeBackend.conn)
+	clients.addBackend(disputepb.DisputeService_ServiceDesc.ServiceName, disputeBackend)
 
 	// Create notification service client
-	notificationConn, err := createConnection(config.NotificationServiceAddr)
+	notificationBackend, err := newBackend("notification", config.NotificationServiceAddr, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to notification service: %w", err)
+		return nil, fmt.Errorf("failed to create notification service client: %w", err)
 	}
-	clients.NotificationClient = notificationpb.NewNotificationServiceClient(notificationConn)
-	clients.connections = append(clients.connections, notificationConn)
+	clients.NotificationClient = notificationpb.NewNotificationServiceClient(notificationBackend.conn)
+	clients.addBackend(notificationpb.NotificationService_ServiceDesc.ServiceName, notificationBackend)
 
 	// Create audit service client
-	auditConn, err := createConnection(config.AuditServiceAddr)
+	auditBackend, err := newBackend("audit", config.AuditServiceAddr, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to audit service: %w", err)
+		return nil, fmt.Errorf("failed to create audit service client: %w", err)
 	}
-	clients.AuditClient = auditpb.NewAuditServiceClient(auditConn)
-	clients.connections = append(clients.connections, auditConn)
+	clients.AuditClient = auditpb.NewAuditServiceClient(auditBackend.conn)
+	clients.addBackend(auditpb.AuditService_ServiceDesc.ServiceName, auditBackend)
 
-	zap.L().Info("All gRPC clients initialized successfully")
+	zap.L().Info("All gRPC clients initialized, connecting in the background")
 	return clients, nil
 }
 
-func createConnection(address string) (*grpc.ClientConn, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+func (c *GRPCClients) addBackend(service string, b *backend) {
+	c.backends = append(c.backends, b)
+	c.serviceBackends[service] = b
+}
 
-	conn, err := grpc.DialContext(ctx, address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+// conn returns the connection serving the named gRPC service, e.g.
+// "contract.ContractService".
+func (c *GRPCClients) conn(service string) (*grpc.ClientConn, bool) {
+	b, ok := c.serviceBackends[service]
+	if !ok {
+		return nil, false
 	}
+	return b.conn, true
+}
 
-	return conn, nil
+// BreakerStatuses returns the state of every backend's circuit breaker.
+func (c *GRPCClients) BreakerStatuses() []BreakerStatus {
+	statuses := make([]BreakerStatus, 0, len(c.backends))
+	for _, b := range c.backends {
+		statuses = append(statuses, b.breaker.Status())
+	}
+	return statuses
 }
 
+// BackendStatuses returns the connection state of every backend.
+func (c *GRPCClients) BackendStatuses() []BackendStatus {
+	statuses := make([]BackendStatus, 0, len(c.backends))
+	for _, b := range c.backends {
+		statuses = append(statuses, b.status())
+	}
+	return statuses
+}
+
+// Close closes the connection of every backend, even if closing one fails,
+// and returns the errors of those that failed.
 func (c *GRPCClients) Close() error {
-	for _, conn := range c.connections {
-		if err := conn.Close(); err != nil {
-			zap.L().Error("Failed to close gRPC connection", zap.Error(err))
-			return err
+	var errs []error
+	for _, b := range c.backends {
+		if err := b.conn.Close(); err != nil {
+			zap.L().Error("Failed to close gRPC connection", zap.String("backend", b.name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", b.name, err))
 		}
 	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
 	zap.L().Info("All gRPC connections closed")
 	return nil
 }
+
+// logAuditEvent records an event with the audit service, adding the request
+// ID to its metadata. Failures are only logged, so it is usually run in its
+// own goroutine with a context detached from the request, e.g. by
+// context.WithoutCancel, that keeps its values and metadata.
+func (c *GRPCClients) logAuditEvent(ctx context.Context, userID, action, resource string, metadata map[string]string, ipAddress, userAgent string) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if requestID := sharedMiddleware.RequestIDFromContext(ctx); requestID != "" {
+		metadata = maps.Clone(metadata)
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata["requestId"] = requestID
+	}
+
+	req := &auditpb.CreateLogRequest{
+		UserId:    userID,
+		Action:    action,
+		Resource:  resource,
+		Metadata:  metadata,
+		IpAddress: ipAddress,
+		UserAgent: userAgent,
+	}
+
+	_, err := c.AuditClient.CreateLog(ctx, req)
+	if err != nil {
+		requestLogger(ctx).Error("Failed to create audit log", zap.Error(err))
+	}
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails" // registers detail types for JSON encoding
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// grpcErrorMapping is the HTTP translation of a gRPC status code.
+type grpcErrorMapping struct {
+	httpStatus int
+	errorCode  string
+}
+
+// Translation of gRPC status codes to HTTP, following grpc-gateway. The
+// error codes match the ones the gateway already uses in its responses.
+var grpcErrorMappings = map[codes.Code]grpcErrorMapping{
+	codes.Canceled:           {499, "REQUEST_CANCELED"},
+	codes.Unknown:            {http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+	codes.InvalidArgument:    {http.StatusBadRequest, "INVALID_REQUEST"},
+	codes.DeadlineExceeded:   {http.StatusGatewayTimeout, "GATEWAY_TIMEOUT"},
+	codes.NotFound:           {http.StatusNotFound, "NOT_FOUND"},
+	codes.AlreadyExists:      {http.StatusConflict, "CONFLICT"},
+	codes.PermissionDenied:   {http.StatusForbidden, "FORBIDDEN"},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, "TOO_MANY_REQUESTS"},
+	codes.FailedPrecondition: {http.StatusBadRequest, "FAILED_PRECONDITION"},
+	codes.Aborted:            {http.StatusConflict, "CONFLICT"},
+	codes.OutOfRange:         {http.StatusBadRequest, "INVALID_REQUEST"},
+	codes.Unimplemented:      {http.StatusNotImplemented, "NOT_IMPLEMENTED"},
+	codes.Internal:           {http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+	codes.Unavailable:        {http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE"},
+	codes.DataLoss:           {http.StatusInternalServerError, "INTERNAL_SERVER_ERROR"},
+	codes.Unauthenticated:    {http.StatusUnauthorized, "UNAUTHORIZED"},
+}
+
+// Business error codes returned in the error field of unsuccessful
+// responses that call for a more specific status than the handler default.
+var businessErrorStatuses = map[string]int{
+	"NOT_FOUND":           http.StatusNotFound,
+	"UNAUTHORIZED":        http.StatusUnauthorized,
+	"INVALID_CREDENTIALS": http.StatusUnauthorized,
+	"FORBIDDEN":           http.StatusForbidden,
+	"CONFLICT":            http.StatusConflict,
+	"ALREADY_EXISTS":      http.StatusConflict,
+}
+
+// result is implemented by the generated response messages that report
+// business failures through their success and error fields.
+type result interface {
+	GetSuccess() bool
+	GetError() string
+}
+
+// respondWithError writes the response for a failed call to the named RPC.
+// The body carries the gRPC code and any error details so clients can tell,
+// for example, a timeout from a validation error.
+func respondWithError(c *gin.Context, rpc string, err error) {
+	st := status.Convert(err)
+	if st.Code() == codes.Unknown {
+		// Context errors raised on the gateway side before the call
+		// reached the backend
+		if ctxStatus := status.FromContextError(err); ctxStatus.Code() != codes.Unknown {
+			st = ctxStatus
+		}
+	}
+
+	mapping, ok := grpcErrorMappings[st.Code()]
+	if !ok {
+		mapping = grpcErrorMappings[codes.Internal]
+	}
+
+	fields := []zap.Field{
+		zap.String("rpc", rpc),
+		zap.String("code", st.Code().String()),
+		zap.String("message", st.Message()),
+	}
+	if mapping.httpStatus >= http.StatusInternalServerError {
+		requestLogger(c).Error("gRPC call failed", fields...)
+	} else {
+		requestLogger(c).Warn("gRPC call failed", fields...)
+	}
+
+	// Backend internals are not exposed to API clients
+	message := st.Message()
+	switch mapping.httpStatus {
+	case http.StatusInternalServerError:
+		message = "Internal server error"
+	case http.StatusServiceUnavailable:
+		message = "Service unavailable"
+	case http.StatusGatewayTimeout:
+		message = "Service timed out"
+	}
+
+	body := gin.H{
+		"success":  false,
+		"error":    mapping.errorCode,
+		"message":  message,
+		"grpcCode": st.Code().String(),
+	}
+	if details := errorDetails(st); len(details) > 0 {
+		body["details"] = details
+	}
+
+	c.JSON(mapping.httpStatus, body)
+}
+
+// respond writes a response message, using successStatus when it reports
+// success and otherwise the status implied by its error code, falling back
+// to failureStatus.
+func respond(c *gin.Context, successStatus, failureStatus int, resp result) {
+	statusCode := successStatus
+	if !resp.GetSuccess() {
+		statusCode = failureStatus
+		if mapped, ok := businessErrorStatuses[resp.GetError()]; ok {
+			statusCode = mapped
+		}
+	}
+
+	c.JSON(statusCode, resp)
+}
+
+// errorDetails encodes the details attached to a status as JSON, keeping
+// only the type of details the gateway has no descriptor for.
+func errorDetails(st *status.Status) []json.RawMessage {
+	anyDetails := st.Proto().GetDetails()
+	details := make([]json.RawMessage, 0, len(anyDetails))
+
+	for _, detail := range anyDetails {
+		encoded, err := protojson.Marshal(detail)
+		if err != nil {
+			encoded, _ = json.Marshal(gin.H{"@type": detail.GetTypeUrl()})
+		}
+		details = append(details, encoded)
+	}
+
+	return details
+}
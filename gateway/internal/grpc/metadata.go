@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"api-gateway/shared/grpcmeta"
+	sharedMiddleware "api-gateway/shared/middleware"
+)
+
+// callContext returns the context of backend calls made for a request. It
+// is derived from the request context, so a client that disconnects cancels
+// the calls, and carries the caller as gRPC metadata.
+func callContext(c *gin.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	return grpcmeta.NewOutgoingContext(ctx, caller(c)), cancel
+}
+
+// caller identifies the request and the user set by the auth middleware.
+func caller(c *gin.Context) grpcmeta.Caller {
+	return grpcmeta.Caller{
+		RequestID: c.GetString(sharedMiddleware.RequestIDKey),
+		UserID:    c.GetString("userID"),
+		Email:     c.GetString("email"),
+		Roles:     c.GetStringSlice("roles"),
+	}
+}
+
+// requestLogger returns the logger of the request, which adds the request ID
+// to every line. ctx may be the gin context or derived from the request
+// context, like the call context.
+func requestLogger(ctx context.Context) *zap.Logger {
+	return sharedMiddleware.RequestLogger(ctx)
+}
@@ -0,0 +1,239 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	contractpb "api-gateway/internal/grpc/contract/proto"
+	disputepb "api-gateway/internal/grpc/dispute/proto"
+	paymentpb "api-gateway/internal/grpc/payment/proto"
+	"api-gateway/internal/middleware"
+)
+
+// Policy decides whether the authenticated user may send a decoded request.
+// It runs after the route's user field is set and before the gRPC call, and
+// may point the request at the resource owner for privileged callers.
+// Rejections are returned as *PolicyError; any other error is treated as a
+// failed gRPC call.
+type Policy func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error
+
+// PolicyError rejects a request with an HTTP status and error code.
+type PolicyError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *PolicyError) Error() string {
+	return e.Message
+}
+
+func forbidden(message string) *PolicyError {
+	return &PolicyError{
+		Status:  http.StatusForbidden,
+		Code:    "FORBIDDEN",
+		Message: message,
+	}
+}
+
+// SelfOrPermission allows the request when the named field is the caller's
+// own user ID, or when the caller holds permission.
+func SelfOrPermission(field, permission string) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		if stringField(req, field) == c.GetString("userID") || middleware.HasPermission(c, permission) {
+			return nil
+		}
+		return forbidden("You can only access your own account")
+	}
+}
+
+// AllOf allows the request when every policy does, checking them in order.
+func AllOf(policies ...Policy) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		for _, policy := range policies {
+			if err := policy(ctx, c, clients, req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// FieldPermission rejects requests that set the named field unless the
+// caller holds permission, e.g. a status only some users may change.
+func FieldPermission(field, permission string) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		if stringField(req, field) == "" || middleware.HasPermission(c, permission) {
+			return nil
+		}
+		return forbidden("Permission " + permission + " is required to set " + field)
+	}
+}
+
+// PartyTo requires the caller's user ID in one of the named fields, e.g. the
+// client or freelancer of a contract being created.
+func PartyTo(fields ...string) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		userID := c.GetString("userID")
+		for _, field := range fields {
+			if stringField(req, field) == userID {
+				return nil
+			}
+		}
+		return forbidden("You must be a party to this contract")
+	}
+}
+
+// ContractParty looks up the contract named by the field and requires the
+// caller to be its client or freelancer.
+func ContractParty(field string) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		userID := c.GetString("userID")
+
+		resp, err := clients.ContractClient.GetContract(ctx, &contractpb.GetContractRequest{
+			ContractId: stringField(req, field),
+			UserId:     userID,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success || resp.Data == nil {
+			return &PolicyError{
+				Status:  http.StatusNotFound,
+				Code:    "NOT_FOUND",
+				Message: "Contract not found",
+			}
+		}
+
+		if resp.Data.ClientId != userID && resp.Data.FreelancerId != userID {
+			return forbidden("You must be a party to this contract")
+		}
+		return nil
+	}
+}
+
+// DisputeParty looks up the dispute named by the field and requires the
+// caller to be its client, freelancer or resolver, or to hold
+// PermissionDisputeResolve.
+func DisputeParty(field string) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		userID := c.GetString("userID")
+
+		resp, err := clients.DisputeClient.GetDispute(ctx, &disputepb.GetDisputeRequest{
+			DisputeId: stringField(req, field),
+			UserId:    userID,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success || resp.Data == nil {
+			return &PolicyError{
+				Status:  http.StatusNotFound,
+				Code:    "NOT_FOUND",
+				Message: "Dispute not found",
+			}
+		}
+
+		switch userID {
+		case resp.Data.ClientId, resp.Data.FreelancerId, resp.Data.ResolverId:
+			return nil
+		}
+		if middleware.HasPermission(c, middleware.PermissionDisputeResolve) {
+			return nil
+		}
+		return forbidden("You must be a party to this dispute")
+	}
+}
+
+// WalletOwner looks up the wallet named by the field and requires the caller
+// to own it or to hold permission. When a permission holder acts on another
+// user's wallet, the request's userId is set to the owner so the payment
+// service acts on their behalf.
+func WalletOwner(field, permission string) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		userID := c.GetString("userID")
+
+		resp, err := clients.PaymentClient.GetWallet(ctx, &paymentpb.GetWalletRequest{
+			WalletId: stringField(req, field),
+			UserId:   userID,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success || resp.Data == nil {
+			return &PolicyError{
+				Status:  http.StatusNotFound,
+				Code:    "NOT_FOUND",
+				Message: "Wallet not found",
+			}
+		}
+
+		if resp.Data.UserId == userID {
+			return nil
+		}
+		if middleware.HasPermission(c, permission) {
+			setStringField(req, "userId", resp.Data.UserId)
+			return nil
+		}
+		return forbidden("You can only access your own wallet")
+	}
+}
+
+// TransactionParty looks up the transaction named by the field and requires
+// the caller to be its sender or recipient, or to hold permission. A
+// permission holder reads the transaction on behalf of its sender.
+func TransactionParty(field, permission string) Policy {
+	return func(ctx context.Context, c *gin.Context, clients *GRPCClients, req proto.Message) error {
+		userID := c.GetString("userID")
+
+		resp, err := clients.PaymentClient.GetTransaction(ctx, &paymentpb.GetTransactionRequest{
+			TransactionId: stringField(req, field),
+			UserId:        userID,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success || resp.Data == nil {
+			return &PolicyError{
+				Status:  http.StatusNotFound,
+				Code:    "NOT_FOUND",
+				Message: "Transaction not found",
+			}
+		}
+
+		if resp.Data.FromUserId == userID || resp.Data.ToUserId == userID {
+			return nil
+		}
+		if middleware.HasPermission(c, permission) {
+			setStringField(req, "userId", resp.Data.FromUserId)
+			return nil
+		}
+		return forbidden("You can only access your own transactions")
+	}
+}
+
+// stringField returns the value of a string field named by its proto or
+// JSON name, or "" if the message has no such field.
+func stringField(msg proto.Message, name string) string {
+	m := msg.ProtoReflect()
+	fd, err := lookupField(m.Descriptor().Fields(), name)
+	if err != nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return m.Get(fd).String()
+}
+
+// setStringField sets a string field named by its proto or JSON name, if the
+// message has one.
+func setStringField(msg proto.Message, name, value string) {
+	m := msg.ProtoReflect()
+	fd, err := lookupField(m.Descriptor().Fields(), name)
+	if err != nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return
+	}
+	m.Set(fd, protoreflect.ValueOfString(value))
+}
@@ -0,0 +1,221 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	contractpb "api-gateway/internal/grpc/contract/proto"
+	disputepb "api-gateway/internal/grpc/dispute/proto"
+	paymentpb "api-gateway/internal/grpc/payment/proto"
+)
+
+type fakeContracts struct {
+	contractpb.ContractServiceClient
+	contracts map[string]*contractpb.ContractData
+}
+
+func (f *fakeContracts) GetContract(_ context.Context, req *contractpb.GetContractRequest, _ ...grpc.CallOption) (*contractpb.GetContractResponse, error) {
+	contract, ok := f.contracts[req.ContractId]
+	if !ok {
+		return &contractpb.GetContractResponse{Success: false, Error: "NOT_FOUND"}, nil
+	}
+	return &contractpb.GetContractResponse{Success: true, Data: contract}, nil
+}
+
+type fakePayments struct {
+	paymentpb.PaymentServiceClient
+	wallets      map[string]*paymentpb.WalletData
+	transactions map[string]*paymentpb.TransactionData
+}
+
+func (f *fakePayments) GetWallet(_ context.Context, req *paymentpb.GetWalletRequest, _ ...grpc.CallOption) (*paymentpb.GetWalletResponse, error) {
+	wallet, ok := f.wallets[req.WalletId]
+	if !ok {
+		return &paymentpb.GetWalletResponse{Success: false, Error: "NOT_FOUND"}, nil
+	}
+	return &paymentpb.GetWalletResponse{Success: true, Data: wallet}, nil
+}
+
+func (f *fakePayments) GetTransaction(_ context.Context, req *paymentpb.GetTransactionRequest, _ ...grpc.CallOption) (*paymentpb.GetTransactionResponse, error) {
+	transaction, ok := f.transactions[req.TransactionId]
+	if !ok {
+		return &paymentpb.GetTransactionResponse{Success: false, Error: "NOT_FOUND"}, nil
+	}
+	return &paymentpb.GetTransactionResponse{Success: true, Data: transaction}, nil
+}
+
+type fakeDisputes struct {
+	disputepb.DisputeServiceClient
+	disputes map[string]*disputepb.DisputeData
+}
+
+func (f *fakeDisputes) GetDispute(_ context.Context, req *disputepb.GetDisputeRequest, _ ...grpc.CallOption) (*disputepb.GetDisputeResponse, error) {
+	dispute, ok := f.disputes[req.DisputeId]
+	if !ok {
+		return &disputepb.GetDisputeResponse{Success: false, Error: "NOT_FOUND"}, nil
+	}
+	return &disputepb.GetDisputeResponse{Success: true, Data: dispute}, nil
+}
+
+// testUser is the authenticated user a policy is checked for.
+type testUser struct {
+	userID string
+	roles  []string
+}
+
+func (u testUser) context() *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("userID", u.userID)
+	c.Set("roles", u.roles)
+	c.Set("permissions", []string(nil))
+	return c
+}
+
+// checkPolicy runs the policy and returns the HTTP status it rejects the
+// request with, or 200 when it allows it.
+func checkPolicy(t *testing.T, policy Policy, clients *GRPCClients, u testUser, req proto.Message) int {
+	t.Helper()
+
+	err := policy(context.Background(), u.context(), clients, req)
+	if err == nil {
+		return http.StatusOK
+	}
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("policy error = %v, want a *PolicyError", err)
+	}
+	return policyErr.Status
+}
+
+func TestUpdateDisputePolicy(t *testing.T) {
+	clients := &GRPCClients{DisputeClient: &fakeDisputes{disputes: map[string]*disputepb.DisputeData{
+		"d1": {Id: "d1", ClientId: "alice", FreelancerId: "bob", ResolverId: "mia"},
+	}}}
+	policy := routePolicy(t, http.MethodPut, "/disputes/:disputeId")
+
+	tests := []struct {
+		name   string
+		caller testUser
+		req    *disputepb.UpdateDisputeRequest
+		want   int
+	}{
+		{
+			name:   "party edits the description",
+			caller: testUser{userID: "alice"},
+			req:    &disputepb.UpdateDisputeRequest{DisputeId: "d1", Description: "details"},
+			want:   http.StatusOK,
+		},
+		{
+			name:   "party changes the status",
+			caller: testUser{userID: "alice"},
+			req:    &disputepb.UpdateDisputeRequest{DisputeId: "d1", Status: "resolved"},
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "mediator changes the status",
+			caller: testUser{userID: "max", roles: []string{"mediator"}},
+			req:    &disputepb.UpdateDisputeRequest{DisputeId: "d1", Status: "resolved"},
+			want:   http.StatusOK,
+		},
+		{
+			name:   "outsider",
+			caller: testUser{userID: "eve"},
+			req:    &disputepb.UpdateDisputeRequest{DisputeId: "d1", Title: "mine now"},
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "unknown dispute",
+			caller: testUser{userID: "alice"},
+			req:    &disputepb.UpdateDisputeRequest{DisputeId: "d2", Title: "title"},
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkPolicy(t, policy, clients, tt.caller, tt.req); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwnershipPolicies(t *testing.T) {
+	clients := &GRPCClients{
+		ContractClient: &fakeContracts{contracts: map[string]*contractpb.ContractData{
+			"c1": {Id: "c1", ClientId: "alice", FreelancerId: "bob"},
+		}},
+		PaymentClient: &fakePayments{
+			wallets: map[string]*paymentpb.WalletData{
+				"w1": {Id: "w1", UserId: "alice"},
+			},
+			transactions: map[string]*paymentpb.TransactionData{
+				"t1": {Id: "t1", FromUserId: "alice", ToUserId: "bob"},
+			},
+		},
+	}
+	admin := testUser{userID: "root", roles: []string{"admin"}}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		caller     testUser
+		req        proto.Message
+		want       int
+		wantUserID string
+	}{
+		{name: "contract party reads", method: http.MethodGet, path: "/contracts/:contractId", caller: testUser{userID: "bob"}, req: &contractpb.GetContractRequest{ContractId: "c1", UserId: "bob"}, want: http.StatusOK},
+		{name: "outsider reads a contract", method: http.MethodGet, path: "/contracts/:contractId", caller: testUser{userID: "eve"}, req: &contractpb.GetContractRequest{ContractId: "c1", UserId: "eve"}, want: http.StatusForbidden},
+		{name: "unknown contract", method: http.MethodGet, path: "/contracts/:contractId", caller: testUser{userID: "alice"}, req: &contractpb.GetContractRequest{ContractId: "c2", UserId: "alice"}, want: http.StatusNotFound},
+
+		{name: "owner reads a wallet", method: http.MethodGet, path: "/wallets/:walletId", caller: testUser{userID: "alice"}, req: &paymentpb.GetWalletRequest{WalletId: "w1", UserId: "alice"}, want: http.StatusOK, wantUserID: "alice"},
+		{name: "outsider reads a wallet", method: http.MethodGet, path: "/wallets/:walletId", caller: testUser{userID: "eve"}, req: &paymentpb.GetWalletRequest{WalletId: "w1", UserId: "eve"}, want: http.StatusForbidden},
+		{name: "admin reads a wallet", method: http.MethodGet, path: "/wallets/:walletId", caller: admin, req: &paymentpb.GetWalletRequest{WalletId: "w1", UserId: "root"}, want: http.StatusOK, wantUserID: "alice"},
+		{name: "auditor reads a wallet", method: http.MethodGet, path: "/wallets/:walletId", caller: testUser{userID: "audra", roles: []string{"auditor"}}, req: &paymentpb.GetWalletRequest{WalletId: "w1", UserId: "audra"}, want: http.StatusForbidden},
+		{name: "unknown wallet", method: http.MethodGet, path: "/wallets/:walletId", caller: admin, req: &paymentpb.GetWalletRequest{WalletId: "w2", UserId: "root"}, want: http.StatusNotFound},
+		{name: "admin updates a wallet", method: http.MethodPut, path: "/wallets/:walletId", caller: admin, req: &paymentpb.UpdateWalletRequest{WalletId: "w1", UserId: "root", Balance: 10}, want: http.StatusOK, wantUserID: "alice"},
+
+		{name: "sender reads a transaction", method: http.MethodGet, path: "/transactions/:transactionId", caller: testUser{userID: "alice"}, req: &paymentpb.GetTransactionRequest{TransactionId: "t1", UserId: "alice"}, want: http.StatusOK, wantUserID: "alice"},
+		{name: "recipient reads a transaction", method: http.MethodGet, path: "/transactions/:transactionId", caller: testUser{userID: "bob"}, req: &paymentpb.GetTransactionRequest{TransactionId: "t1", UserId: "bob"}, want: http.StatusOK, wantUserID: "bob"},
+		{name: "outsider reads a transaction", method: http.MethodGet, path: "/transactions/:transactionId", caller: testUser{userID: "eve"}, req: &paymentpb.GetTransactionRequest{TransactionId: "t1", UserId: "eve"}, want: http.StatusForbidden},
+		{name: "admin reads a transaction", method: http.MethodGet, path: "/transactions/:transactionId", caller: admin, req: &paymentpb.GetTransactionRequest{TransactionId: "t1", UserId: "root"}, want: http.StatusOK, wantUserID: "alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := routePolicy(t, tt.method, tt.path)
+			if got := checkPolicy(t, policy, clients, tt.caller, tt.req); got != tt.want {
+				t.Fatalf("status = %d, want %d", got, tt.want)
+			}
+			if tt.wantUserID != "" {
+				if got := stringField(tt.req, "userId"); got != tt.wantUserID {
+					t.Errorf("userId = %q, want %q", got, tt.wantUserID)
+				}
+			}
+		})
+	}
+}
+
+// routePolicy returns the policy of the route table entry.
+func routePolicy(t *testing.T, method, path string) Policy {
+	t.Helper()
+
+	for _, route := range APIRoutes {
+		if route.Method == method && route.Path == path {
+			if route.Policy == nil {
+				t.Fatalf("%s %s has no policy", method, path)
+			}
+			return route.Policy
+		}
+	}
+	t.Fatalf("no route %s %s", method, path)
+	return nil
+}
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/resolver"
+)
+
+// Interval between reads of an address file.
+const fileResolverInterval = 30 * time.Second
+
+// backendTarget turns a configured backend address into a gRPC target:
+//
+//   - "host:port" is resolved through DNS, like "dns:///host:port"
+//   - "host1:port,host2:port" is a static list of addresses
+//   - "file:///path" reads the addresses from a file, one per line
+//   - any other URI, e.g. "dns:///host:port", is used as is
+func backendTarget(address string) string {
+	if strings.Contains(address, "://") {
+		return address
+	}
+	if strings.Contains(address, ",") {
+		return staticScheme + ":///" + address
+	}
+	return address
+}
+
+func splitAddresses(list string) []resolver.Address {
+	var addresses []resolver.Address
+	for _, address := range strings.Split(list, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, resolver.Address{Addr: address})
+		}
+	}
+	return addresses
+}
+
+const staticScheme = "static"
+
+// staticResolverBuilder resolves "static:///host1:port,host2:port" to the
+// listed addresses.
+type staticResolverBuilder struct{}
+
+func (staticResolverBuilder) Scheme() string {
+	return staticScheme
+}
+
+func (staticResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	addresses := splitAddresses(target.Endpoint())
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no addresses in target %s", target.String())
+	}
+
+	if err := cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		return nil, err
+	}
+	return staticResolver{}, nil
+}
+
+type staticResolver struct{}
+
+func (staticResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (staticResolver) Close() {}
+
+const fileScheme = "file"
+
+// fileResolverBuilder resolves "file:///path" to the addresses listed in the
+// file, one per line, with "#" starting a comment. The file is read again
+// periodically and when gRPC asks to re-resolve, so replicas can be added
+// without restarting the gateway.
+type fileResolverBuilder struct{}
+
+func (fileResolverBuilder) Scheme() string {
+	return fileScheme
+}
+
+func (fileResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	r := &fileResolver{
+		path:    target.URL.Path,
+		cc:      cc,
+		resolve: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	r.update()
+	go r.run()
+	return r, nil
+}
+
+type fileResolver struct {
+	path    string
+	cc      resolver.ClientConn
+	resolve chan struct{}
+	done    chan struct{}
+}
+
+func (r *fileResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolve <- struct{}{}:
+	default:
+	}
+}
+
+func (r *fileResolver) Close() {
+	close(r.done)
+}
+
+func (r *fileResolver) run() {
+	ticker := time.NewTicker(fileResolverInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		case <-r.resolve:
+		}
+		r.update()
+	}
+}
+
+// update reads the file and passes its addresses to gRPC. On failure the
+// previous addresses stay in use.
+func (r *fileResolver) update() {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		zap.L().Error("Failed to read backend address file", zap.String("path", r.path), zap.Error(err))
+		r.cc.ReportError(err)
+		return
+	}
+
+	var addresses []resolver.Address
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		addresses = append(addresses, splitAddresses(line)...)
+	}
+	if len(addresses) == 0 {
+		err := fmt.Errorf("no addresses in %s", r.path)
+		zap.L().Error("Backend address file is empty", zap.String("path", r.path))
+		r.cc.ReportError(err)
+		return
+	}
+
+	if err := r.cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		zap.L().Error("Failed to update backend addresses", zap.String("path", r.path), zap.Error(err))
+	}
+}
@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy retries idempotent calls that failed because the backend was
+// unavailable or too slow.
+type RetryPolicy struct {
+	// MaxAttempts includes the first call.
+	MaxAttempts int
+
+	// The wait before the nth retry is random between zero and
+	// BaseDelay*2^(n-1), capped at MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// AttemptTimeout bounds each attempt so a hung backend is retried
+	// rather than holding the call until its overall deadline. A call may
+	// override it with WithAttemptTimeout.
+	AttemptTimeout time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	BaseDelay:      100 * time.Millisecond,
+	MaxDelay:       time.Second,
+	AttemptTimeout: 5 * time.Second,
+}
+
+// attemptTimeoutOption overrides the AttemptTimeout of the retry policy for
+// one call.
+type attemptTimeoutOption struct {
+	grpc.EmptyCallOption
+	timeout time.Duration
+}
+
+// WithAttemptTimeout bounds each attempt of a retried call by timeout
+// instead of the AttemptTimeout of the retry policy, for methods known to be
+// slower than most.
+func WithAttemptTimeout(timeout time.Duration) grpc.CallOption {
+	return attemptTimeoutOption{timeout: timeout}
+}
+
+// attemptTimeout returns the timeout of each attempt of a call.
+func attemptTimeout(policy RetryPolicy, opts []grpc.CallOption) time.Duration {
+	timeout := policy.AttemptTimeout
+	for _, opt := range opts {
+		if o, ok := opt.(attemptTimeoutOption); ok {
+			timeout = o.timeout
+		}
+	}
+	return timeout
+}
+
+// retryInterceptor retries the Get* methods, which have no side effects.
+// Other methods are called once.
+func retryInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !isIdempotent(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		timeout := attemptTimeout(policy, opts)
+
+		var err error
+		for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+			if attempt > 0 {
+				delay := retryDelay(policy, attempt)
+				if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+					return err
+				}
+
+				zap.L().Warn("Retrying gRPC call",
+					zap.String("method", method),
+					zap.Int("attempt", attempt+1),
+					zap.String("code", status.Code(err).String()),
+					zap.Duration("delay", delay),
+				)
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+			}
+
+			err = invokeAttempt(ctx, timeout, method, req, reply, cc, invoker, opts...)
+			if !retryable(ctx, err) {
+				return err
+			}
+		}
+		return err
+	}
+}
+
+func invokeAttempt(ctx context.Context, timeout time.Duration, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// retryable reports whether a failed attempt is worth repeating: the backend
+// was unavailable, or the attempt timed out while the call still has time.
+func retryable(ctx context.Context, err error) bool {
+	if err == nil || errors.Is(err, errCircuitOpen) || ctx.Err() != nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether the full method name, e.g.
+// "/contract.ContractService/GetContract", names a Get* method.
+func isIdempotent(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	return strings.HasPrefix(name, "Get")
+}
+
+func retryDelay(policy RetryPolicy, attempt int) time.Duration {
+	limit := policy.BaseDelay << (attempt - 1)
+	if limit <= 0 || limit > policy.MaxDelay {
+		limit = policy.MaxDelay
+	}
+	return rand.N(limit + 1)
+}
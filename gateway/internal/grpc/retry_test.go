@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	BaseDelay:      time.Millisecond,
+	MaxDelay:       2 * time.Millisecond,
+	AttemptTimeout: 5 * time.Second,
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "")
+
+	tests := []struct {
+		name   string
+		method string
+		// errs are returned by the successive attempts, nil once exhausted
+		errs         []error
+		wantAttempts int
+		wantCode     codes.Code
+	}{
+		{
+			name:         "success",
+			method:       "/contract.ContractService/GetContract",
+			wantAttempts: 1,
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "unavailable once",
+			method:       "/contract.ContractService/GetContract",
+			errs:         []error{unavailable},
+			wantAttempts: 2,
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "attempt timed out",
+			method:       "/contract.ContractService/GetContract",
+			errs:         []error{status.Error(codes.DeadlineExceeded, "")},
+			wantAttempts: 2,
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "unavailable every time",
+			method:       "/contract.ContractService/GetContract",
+			errs:         []error{unavailable, unavailable, unavailable, unavailable},
+			wantAttempts: 3,
+			wantCode:     codes.Unavailable,
+		},
+		{
+			name:         "business error",
+			method:       "/contract.ContractService/GetContract",
+			errs:         []error{status.Error(codes.NotFound, "")},
+			wantAttempts: 1,
+			wantCode:     codes.NotFound,
+		},
+		{
+			name:         "circuit open",
+			method:       "/contract.ContractService/GetContract",
+			errs:         []error{errCircuitOpen},
+			wantAttempts: 1,
+			wantCode:     codes.Unavailable,
+		},
+		{
+			name:         "not idempotent",
+			method:       "/contract.ContractService/CreateContract",
+			errs:         []error{unavailable},
+			wantAttempts: 1,
+			wantCode:     codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			}
+
+			err := retryInterceptor(testRetryPolicy)(context.Background(), tt.method, nil, nil, nil, invoker)
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRetryInterceptorAttemptTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []grpc.CallOption
+		want time.Duration
+	}{
+		{name: "policy", want: testRetryPolicy.AttemptTimeout},
+		{name: "call option", opts: []grpc.CallOption{WithAttemptTimeout(30 * time.Second)}, want: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got time.Duration
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				deadline, ok := ctx.Deadline()
+				if !ok {
+					t.Fatal("attempt without deadline")
+				}
+				got = time.Until(deadline)
+				return nil
+			}
+
+			err := retryInterceptor(testRetryPolicy)(context.Background(), "/audit.AuditService/GetLogs", nil, nil, nil, invoker, tt.opts...)
+			if err != nil {
+				t.Fatalf("call: %v", err)
+			}
+			if got > tt.want || got < tt.want-time.Second {
+				t.Errorf("attempt timeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
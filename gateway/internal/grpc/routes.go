@@ -0,0 +1,295 @@
+package grpc
+
+import (
+	"net/http"
+	"time"
+
+	"api-gateway/internal/auth"
+	"api-gateway/internal/middleware"
+	"api-gateway/internal/ratelimit"
+)
+
+// Rate limits, per user on authenticated routes and per client IP otherwise.
+var (
+	// IPRateLimit applies per client IP to every route under /api/v1/auth
+	// and, before the token is verified, to every authenticated route, so
+	// requests with invalid tokens are throttled too.
+	IPRateLimit = ratelimit.Policy{Name: "ip", Requests: 600, Period: time.Minute}
+
+	// APIRateLimit applies to every authenticated route.
+	APIRateLimit = ratelimit.Policy{Name: "api", Requests: 300, Period: time.Minute}
+
+	LoginRateLimit    = ratelimit.Policy{Name: "login", Requests: 10, Period: time.Minute}
+	RefreshRateLimit  = ratelimit.Policy{Name: "refresh", Requests: 30, Period: time.Minute}
+	ValidateRateLimit = ratelimit.Policy{Name: "validate", Requests: 60, Period: time.Minute}
+	RegisterRateLimit = ratelimit.Policy{Name: "register", Requests: 10, Period: time.Hour}
+	TransferRateLimit = ratelimit.Policy{Name: "transfers", Requests: 10, Period: time.Minute, Burst: 5}
+)
+
+// Backoff and lockout of failed logins. An IP gets more attempts than an
+// email since many users may share it.
+var (
+	EmailLoginLockout = auth.LockoutPolicy{
+		FreeAttempts:     3,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  15 * time.Minute,
+		Window:           15 * time.Minute,
+	}
+	IPLoginLockout = auth.LockoutPolicy{
+		FreeAttempts:     10,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		LockoutThreshold: 50,
+		LockoutDuration:  time.Hour,
+		Window:           time.Hour,
+	}
+)
+
+// Audit log queries may scan many entries. Each attempt gets the whole route
+// timeout, so a slow query is not cut off and sent again.
+const auditQueryTimeout = defaultRouteTimeout
+
+// AuthRoutes are served under /api/v1/auth without authentication. Login,
+// refresh and logout are served by SessionHandler.
+var AuthRoutes = []Route{
+	{
+		Method:    http.MethodPost,
+		Path:      "/register",
+		RPC:       "auth.AuthService/Register",
+		Body:      "*",
+		RateLimit: &RegisterRateLimit,
+	},
+}
+
+// APIRoutes are served under /api/v1 behind the auth middleware.
+var APIRoutes = []Route{
+	// User routes
+	{
+		Method:        http.MethodGet,
+		Path:          "/users/:userId",
+		RPC:           "auth.AuthService/GetUser",
+		FailureStatus: http.StatusNotFound,
+		Policy:        SelfOrPermission("userId", middleware.PermissionUserManage),
+	},
+	{
+		Method: http.MethodPut,
+		Path:   "/users/:userId",
+		RPC:    "auth.AuthService/UpdateUser",
+		Body:   "*",
+		Policy: SelfOrPermission("userId", middleware.PermissionUserManage),
+		Audit: &AuditEvent{
+			Action:   "UPDATE_USER",
+			Resource: "user",
+			Fields:   []string{"userId"},
+		},
+	},
+
+	// Contract routes
+	{
+		Method:        http.MethodPost,
+		Path:          "/contracts",
+		RPC:           "contract.ContractService/CreateContract",
+		Body:          "*",
+		UserField:     "userId",
+		SuccessStatus: http.StatusCreated,
+		Policy:        PartyTo("clientId", "freelancerId"),
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/contracts",
+		RPC:       "contract.ContractService/GetContracts",
+		UserField: "userId",
+		Defaults:  map[string]string{"page": "1", "limit": "10"},
+	},
+	{
+		Method:        http.MethodGet,
+		Path:          "/contracts/:contractId",
+		RPC:           "contract.ContractService/GetContract",
+		UserField:     "userId",
+		FailureStatus: http.StatusNotFound,
+		Policy:        ContractParty("contractId"),
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/contracts/:contractId",
+		RPC:       "contract.ContractService/UpdateContract",
+		Body:      "*",
+		UserField: "userId",
+		Policy:    ContractParty("contractId"),
+		Audit: &AuditEvent{
+			Action:   "UPDATE_CONTRACT",
+			Resource: "contract",
+			Fields:   []string{"contractId", "status"},
+		},
+	},
+	{
+		Method:    http.MethodDelete,
+		Path:      "/contracts/:contractId",
+		RPC:       "contract.ContractService/DeleteContract",
+		UserField: "userId",
+		Policy:    ContractParty("contractId"),
+		Audit: &AuditEvent{
+			Action:   "DELETE_CONTRACT",
+			Resource: "contract",
+			Fields:   []string{"contractId"},
+		},
+	},
+
+	// Payment routes
+	{
+		Method:        http.MethodPost,
+		Path:          "/wallets",
+		RPC:           "payment.PaymentService/CreateWallet",
+		Body:          "*",
+		UserField:     "userId",
+		SuccessStatus: http.StatusCreated,
+	},
+	{
+		Method:        http.MethodGet,
+		Path:          "/wallets/:walletId",
+		RPC:           "payment.PaymentService/GetWallet",
+		UserField:     "userId",
+		FailureStatus: http.StatusNotFound,
+		Policy:        WalletOwner("walletId", middleware.PermissionPaymentRead),
+	},
+	{
+		Method:     http.MethodPut,
+		Path:       "/wallets/:walletId",
+		RPC:        "payment.PaymentService/UpdateWallet",
+		Body:       "*",
+		UserField:  "userId",
+		Permission: middleware.PermissionWalletWrite,
+		Policy:     WalletOwner("walletId", middleware.PermissionWalletWrite),
+		Audit: &AuditEvent{
+			Action:   "UPDATE_WALLET",
+			Resource: "payment",
+			Fields:   []string{"walletId", "balance"},
+		},
+	},
+	{
+		Method:        http.MethodPost,
+		Path:          "/transfers",
+		RPC:           "payment.PaymentService/CreateTransfer",
+		Body:          "*",
+		UserField:     "fromUserId",
+		SuccessStatus: http.StatusCreated,
+		RateLimit:     &TransferRateLimit,
+		Audit: &AuditEvent{
+			Action:   "CREATE_TRANSFER",
+			Resource: "payment",
+			Fields:   []string{"amount", "currency", "toUserId"},
+		},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/transactions",
+		RPC:       "payment.PaymentService/GetTransactions",
+		UserField: "userId",
+		Defaults:  map[string]string{"page": "1", "limit": "10"},
+	},
+	{
+		Method:        http.MethodGet,
+		Path:          "/transactions/:transactionId",
+		RPC:           "payment.PaymentService/GetTransaction",
+		UserField:     "userId",
+		FailureStatus: http.StatusNotFound,
+		Policy:        TransactionParty("transactionId", middleware.PermissionPaymentRead),
+	},
+
+	// Dispute routes
+	{
+		Method:        http.MethodPost,
+		Path:          "/disputes",
+		RPC:           "dispute.DisputeService/CreateDispute",
+		Body:          "*",
+		UserField:     "userId",
+		SuccessStatus: http.StatusCreated,
+		Policy:        ContractParty("contractId"),
+		Audit: &AuditEvent{
+			Action:   "CREATE_DISPUTE",
+			Resource: "dispute",
+			Fields:   []string{"contractId", "category"},
+		},
+	},
+	{
+		Method:    http.MethodGet,
+		Path:      "/disputes",
+		RPC:       "dispute.DisputeService/GetDisputes",
+		UserField: "userId",
+		Defaults:  map[string]string{"page": "1", "limit": "10"},
+	},
+	{
+		Method:        http.MethodGet,
+		Path:          "/disputes/:disputeId",
+		RPC:           "dispute.DisputeService/GetDispute",
+		UserField:     "userId",
+		FailureStatus: http.StatusNotFound,
+		Policy:        DisputeParty("disputeId"),
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/disputes/:disputeId",
+		RPC:       "dispute.DisputeService/UpdateDispute",
+		Body:      "*",
+		UserField: "userId",
+		// Parties may edit the title and description; moving the dispute
+		// through its states is for mediators
+		Policy: AllOf(
+			FieldPermission("status", middleware.PermissionDisputeResolve),
+			DisputeParty("disputeId"),
+		),
+		Audit: &AuditEvent{
+			Action:   "UPDATE_DISPUTE",
+			Resource: "dispute",
+			Fields:   []string{"disputeId", "status"},
+		},
+	},
+	{
+		Method:     http.MethodPut,
+		Path:       "/disputes/:disputeId/resolve",
+		RPC:        "dispute.DisputeService/ResolveDispute",
+		Body:       "*",
+		UserField:  "resolverId",
+		Permission: middleware.PermissionDisputeResolve,
+		Audit: &AuditEvent{
+			Action:   "RESOLVE_DISPUTE",
+			Resource: "dispute",
+			Fields:   []string{"disputeId", "outcome"},
+		},
+	},
+
+	// Notification routes
+	{
+		Method:    http.MethodGet,
+		Path:      "/notifications",
+		RPC:       "notification.NotificationService/GetNotifications",
+		UserField: "userId",
+		Defaults:  map[string]string{"page": "1", "limit": "10"},
+	},
+	{
+		Method:    http.MethodPut,
+		Path:      "/notifications/:notificationId/read",
+		RPC:       "notification.NotificationService/MarkAsRead",
+		UserField: "userId",
+	},
+
+	// Audit routes
+	{
+		Method:         http.MethodGet,
+		Path:           "/audit/logs",
+		RPC:            "audit.AuditService/GetLogs",
+		Defaults:       map[string]string{"page": "1", "limit": "10"},
+		Permission:     middleware.PermissionAuditRead,
+		AttemptTimeout: auditQueryTimeout,
+	},
+	{
+		Method:         http.MethodGet,
+		Path:           "/audit/users/:userId/logs",
+		RPC:            "audit.AuditService/GetLogsByUser",
+		Defaults:       map[string]string{"page": "1", "limit": "10"},
+		Permission:     middleware.PermissionAuditRead,
+		AttemptTimeout: auditQueryTimeout,
+	},
+}
@@ -0,0 +1,379 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"api-gateway/internal/auth"
+	authpb "api-gateway/internal/grpc/auth/proto"
+	"api-gateway/shared/jwtauth"
+)
+
+var errUnknownUser = errors.New("user no longer exists")
+
+// SessionHandler serves login, token refresh and logout. The auth service
+// only issues access tokens, so refresh tokens are issued and rotated here.
+type SessionHandler struct {
+	clients     *GRPCClients
+	verifier    *auth.Verifier
+	issuer      *auth.TokenIssuer
+	revocations jwtauth.RevocationList
+	guard       *auth.LoginGuard
+}
+
+// NewSessionHandler returns a handler for the session routes. Without an
+// issuer, logins return no refresh token and refresh is unavailable. guard
+// throttles failed logins.
+func NewSessionHandler(clients *GRPCClients, verifier *auth.Verifier, issuer *auth.TokenIssuer, revocations jwtauth.RevocationList, guard *auth.LoginGuard) *SessionHandler {
+	return &SessionHandler{
+		clients:     clients,
+		verifier:    verifier,
+		issuer:      issuer,
+		revocations: revocations,
+		guard:       guard,
+	}
+}
+
+type loginData struct {
+	AccessToken  string           `json:"accessToken"`
+	RefreshToken string           `json:"refreshToken,omitempty"`
+	User         *authpb.UserData `json:"user,omitempty"`
+}
+
+// Login forwards the credentials to the auth service and adds a refresh token
+// to a successful response.
+func (h *SessionHandler) Login(c *gin.Context) {
+	var reqData struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err := c.ShouldBindJSON(&reqData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "INVALID_REQUEST",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	ctx, cancel := callContext(c, 30*time.Second)
+	defer cancel()
+
+	// Attempts in progress count towards the lockout, so concurrent
+	// attempts cannot slip past it while the auth call runs
+	ip := c.ClientIP()
+	attempt, block, err := h.guard.Begin(ctx, reqData.Email, ip)
+	if err != nil {
+		requestLogger(ctx).Error("Failed to check login failures", zap.Error(err))
+	} else if block != nil {
+		respondLoginBlocked(c, block)
+		return
+	}
+
+	req := &authpb.LoginRequest{
+		Email:    reqData.Email,
+		Password: reqData.Password,
+	}
+
+	resp, err := h.clients.AuthClient.Login(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			h.recordFailure(ctx, c, attempt, reqData.Email, ip)
+		} else {
+			h.cancelAttempt(ctx, attempt)
+		}
+		respondWithError(c, "Login", err)
+		return
+	}
+
+	if !resp.Success || resp.Data == nil {
+		h.recordFailure(ctx, c, attempt, reqData.Email, ip)
+		respond(c, http.StatusOK, http.StatusUnauthorized, resp)
+		return
+	}
+
+	if attempt != nil {
+		if err := attempt.Succeed(context.WithoutCancel(ctx)); err != nil {
+			requestLogger(ctx).Error("Failed to reset login failures", zap.Error(err))
+		}
+	}
+
+	data := loginData{
+		AccessToken: resp.Data.AccessToken,
+		User:        resp.Data.User,
+	}
+
+	if h.issuer != nil {
+		// The refresh token carries the identity of the access token, so the
+		// token must verify here as it will on every later request
+		claims, err := h.verifier.Verify(ctx, resp.Data.AccessToken)
+		if err != nil {
+			requestLogger(ctx).Error("Auth service issued a token the gateway rejects", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "INTERNAL_SERVER_ERROR",
+				"message": "Failed to create session",
+			})
+			return
+		}
+
+		data.RefreshToken, err = h.issuer.IssueRefreshToken(claims)
+		if err != nil {
+			requestLogger(ctx).Error("Failed to issue refresh token", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "INTERNAL_SERVER_ERROR",
+				"message": "Failed to create session",
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"data":    data,
+	})
+}
+
+// recordFailure records the failure of a rejected login and audits the
+// lockouts it starts. attempt is nil when it could not be started.
+func (h *SessionHandler) recordFailure(ctx context.Context, c *gin.Context, attempt *auth.LoginAttempt, email, ip string) {
+	if attempt == nil {
+		return
+	}
+
+	// Record and audit even if the client has gone
+	ctx = context.WithoutCancel(ctx)
+
+	lockouts, err := attempt.Fail(ctx)
+	if err != nil {
+		requestLogger(ctx).Error("Failed to record login failure", zap.Error(err))
+	}
+	for _, lockout := range lockouts {
+		requestLogger(ctx).Warn("Login locked",
+			zap.String("scope", lockout.Scope),
+			zap.String("ip", ip),
+			zap.Duration("duration", lockout.RetryAfter),
+		)
+		go h.clients.logAuditEvent(ctx, "", "LOGIN_LOCKED", "auth", map[string]string{
+			"email":    email,
+			"scope":    lockout.Scope,
+			"duration": lockout.RetryAfter.String(),
+		}, ip, c.Request.UserAgent())
+	}
+}
+
+// cancelAttempt takes back an attempt whose credentials the auth service
+// could not check.
+func (h *SessionHandler) cancelAttempt(ctx context.Context, attempt *auth.LoginAttempt) {
+	if attempt == nil {
+		return
+	}
+	if err := attempt.Cancel(context.WithoutCancel(ctx)); err != nil {
+		requestLogger(ctx).Error("Failed to release login attempt", zap.Error(err))
+	}
+}
+
+func respondLoginBlocked(c *gin.Context, block *auth.LoginBlock) {
+	retryAfter := int(math.Ceil(block.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+	errorCode, message := "TOO_MANY_REQUESTS", "Too many failed login attempts"
+	if block.Locked {
+		errorCode, message = "LOGIN_LOCKED", "Login is temporarily locked after too many failed attempts"
+	}
+
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"success":    false,
+		"error":      errorCode,
+		"message":    message,
+		"retryAfter": retryAfter,
+	})
+}
+
+// Refresh exchanges a refresh token for a new token pair. The old refresh
+// token is claimed atomically so each one can be used only once, and the user
+// is looked up again so deleted accounts cannot refresh. Roles are carried
+// over from the login, which the issuer's MaxSessionAge bounds.
+func (h *SessionHandler) Refresh(c *gin.Context) {
+	if h.issuer == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{
+			"success": false,
+			"error":   "NOT_IMPLEMENTED",
+			"message": "Token refresh is not enabled",
+		})
+		return
+	}
+
+	var reqData struct {
+		RefreshToken string `json:"refreshToken" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&reqData); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "INVALID_REQUEST",
+			"message": "refreshToken is required",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	claims, err := h.verifier.VerifyRefresh(ctx, reqData.RefreshToken)
+	if err != nil {
+		requestLogger(ctx).Warn("Refresh token rejected", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "UNAUTHORIZED",
+			"message": "Invalid or expired refresh token",
+		})
+		return
+	}
+
+	identity, err := h.currentIdentity(c, claims)
+	if errors.Is(err, errUnknownUser) {
+		requestLogger(ctx).Warn("Refresh token of an unknown user", zap.String("userId", claims.UserID))
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "UNAUTHORIZED",
+			"message": "Invalid or expired refresh token",
+		})
+		return
+	}
+	if err != nil {
+		respondWithError(c, "Refresh", err)
+		return
+	}
+
+	claimed, err := h.revocations.ClaimToken(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		requestLogger(ctx).Error("Failed to revoke refresh token", zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error":   "SERVICE_UNAVAILABLE",
+			"message": "Failed to refresh session",
+		})
+		return
+	}
+	if !claimed {
+		requestLogger(ctx).Warn("Refresh token reused", zap.String("userId", claims.UserID))
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "UNAUTHORIZED",
+			"message": "Invalid or expired refresh token",
+		})
+		return
+	}
+
+	pair, err := h.issuer.IssuePair(identity)
+	if err != nil {
+		requestLogger(ctx).Error("Failed to issue token pair", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "INTERNAL_SERVER_ERROR",
+			"message": "Failed to refresh session",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    pair,
+	})
+}
+
+// currentIdentity returns the identity of a refresh token updated from the
+// auth service, or errUnknownUser if the user no longer exists.
+func (h *SessionHandler) currentIdentity(c *gin.Context, claims *auth.Claims) (*auth.Claims, error) {
+	ctx, cancel := callContext(c, 10*time.Second)
+	defer cancel()
+
+	resp, err := h.clients.AuthClient.GetUser(ctx, &authpb.GetUserRequest{UserId: claims.UserID})
+	if status.Code(err) == codes.NotFound || (err == nil && (!resp.Success || resp.Data == nil)) {
+		return nil, errUnknownUser
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity := *claims
+	identity.Email = resp.Data.Email
+	return &identity, nil
+}
+
+// Logout revokes the access token of the request and, if given, the refresh
+// token of the session. With allDevices every token of the user issued so far
+// is revoked. It must run behind the auth middleware.
+func (h *SessionHandler) Logout(c *gin.Context) {
+	var reqData struct {
+		RefreshToken string `json:"refreshToken"`
+		AllDevices   bool   `json:"allDevices"`
+	}
+
+	// The body is optional
+	if err := c.ShouldBindJSON(&reqData); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "INVALID_REQUEST",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	claims := c.MustGet("claims").(*auth.Claims)
+
+	revoked := []*auth.Claims{claims}
+	if reqData.RefreshToken != "" {
+		refreshClaims, err := h.verifier.VerifyRefresh(ctx, reqData.RefreshToken)
+		if err == nil && refreshClaims.UserID == claims.UserID {
+			revoked = append(revoked, refreshClaims)
+		}
+	}
+
+	var err error
+	if reqData.AllDevices || claims.ID == "" {
+		// Tokens without a jti can only be revoked together with the
+		// user's other tokens
+		err = h.revocations.RevokeUserTokens(ctx, claims.UserID, time.Now())
+	}
+
+	// The user cutoff spares tokens issued during the current second, so
+	// the tokens of this session are revoked by jti as well
+	for _, token := range revoked {
+		if err != nil {
+			break
+		}
+		if token.ID != "" {
+			err = h.revocations.RevokeToken(ctx, token.ID, token.ExpiresAt.Time)
+		}
+	}
+
+	if err != nil {
+		requestLogger(ctx).Error("Failed to revoke tokens", zap.String("userId", claims.UserID), zap.Error(err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error":   "SERVICE_UNAVAILABLE",
+			"message": "Failed to log out",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Logged out",
+	})
+}
@@ -0,0 +1,239 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+
+	"api-gateway/internal/auth"
+	authpb "api-gateway/internal/grpc/auth/proto"
+	"api-gateway/shared/jwtauth"
+)
+
+const testSecret = "test-secret"
+
+type fakeAuth struct {
+	authpb.AuthServiceClient
+	users map[string]*authpb.UserData
+}
+
+func (f *fakeAuth) GetUser(_ context.Context, req *authpb.GetUserRequest, _ ...grpc.CallOption) (*authpb.GetUserResponse, error) {
+	user, ok := f.users[req.UserId]
+	if !ok {
+		return &authpb.GetUserResponse{Success: false, Error: "NOT_FOUND"}, nil
+	}
+	return &authpb.GetUserResponse{Success: true, Data: user}, nil
+}
+
+// testSession is a session handler backed by an in-memory revocation list
+// and an auth service that knows alice.
+type testSession struct {
+	handler  *SessionHandler
+	verifier *auth.Verifier
+	issuer   *auth.TokenIssuer
+}
+
+func newTestSession(t *testing.T) *testSession {
+	t.Helper()
+
+	revocations := jwtauth.NewMemoryRevocationList()
+	verifier, err := auth.NewVerifier(auth.VerifierConfig{
+		Config:      jwtauth.Config{HMACSecret: testSecret},
+		Revocations: revocations,
+	})
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+	issuer := auth.NewTokenIssuer(auth.IssuerConfig{
+		Secret:          testSecret,
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	})
+	clients := &GRPCClients{AuthClient: &fakeAuth{users: map[string]*authpb.UserData{
+		"alice": {Id: "alice", Email: "alice@example.com"},
+	}}}
+
+	return &testSession{
+		handler:  NewSessionHandler(clients, verifier, issuer, revocations, nil),
+		verifier: verifier,
+		issuer:   issuer,
+	}
+}
+
+// pair issues a token pair for userID.
+func (s *testSession) pair(t *testing.T, userID string) *auth.TokenPair {
+	t.Helper()
+
+	pair, err := s.issuer.IssuePair(&auth.Claims{UserID: userID})
+	if err != nil {
+		t.Fatalf("IssuePair: %v", err)
+	}
+	return pair
+}
+
+// accessToken signs an access token of alice issued at the given time.
+func accessToken(t *testing.T, jti string, issuedAt time.Time) string {
+	t.Helper()
+
+	claims := jwt.MapClaims{
+		"sub": "alice",
+		"iat": issuedAt.Unix(),
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}
+	if jti != "" {
+		claims["jti"] = jti
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return token
+}
+
+// serve runs handler on a POST with the given body. A non-empty token is
+// verified and stored in the context as the auth middleware would.
+func (s *testSession) serve(t *testing.T, handler gin.HandlerFunc, token, body string) int {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		claims, err := s.verifier.Verify(context.Background(), token)
+		if err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+		c.Set("userID", claims.UserID)
+		c.Set("claims", claims)
+	}
+
+	handler(c)
+	return w.Code
+}
+
+func (s *testSession) valid(token string) bool {
+	_, err := s.verifier.Verify(context.Background(), token)
+	return err == nil
+}
+
+func TestRefresh(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      func(t *testing.T, s *testSession) string
+		wantStatus int
+	}{
+		{
+			name:       "refresh token",
+			token:      func(t *testing.T, s *testSession) string { return s.pair(t, "alice").RefreshToken },
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "reused refresh token",
+			token: func(t *testing.T, s *testSession) string {
+				token := s.pair(t, "alice").RefreshToken
+				s.serve(t, s.handler.Refresh, "", `{"refreshToken":"`+token+`"}`)
+				return token
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "access token",
+			token:      func(t *testing.T, s *testSession) string { return s.pair(t, "alice").AccessToken },
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "deleted user",
+			token:      func(t *testing.T, s *testSession) string { return s.pair(t, "bob").RefreshToken },
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSession(t)
+			body := `{"refreshToken":"` + tt.token(t, s) + `"}`
+			if got := s.serve(t, s.handler.Refresh, "", body); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		// token logs out; other is another token of the same user
+		token, other string
+		allDevices   bool
+		sendRefresh  bool
+		wantOther    bool
+	}{
+		{
+			name:      "this device",
+			token:     accessToken(t, "current", now),
+			other:     accessToken(t, "other", now.Add(-time.Minute)),
+			wantOther: true,
+		},
+		{
+			name:        "this device with its refresh token",
+			token:       accessToken(t, "current", now),
+			other:       accessToken(t, "other", now.Add(-time.Minute)),
+			sendRefresh: true,
+			wantOther:   true,
+		},
+		{
+			name:       "all devices",
+			token:      accessToken(t, "current", now),
+			other:      accessToken(t, "other", now.Add(-time.Minute)),
+			allDevices: true,
+		},
+		{
+			name:  "token without jti",
+			token: accessToken(t, "", now.Add(-time.Second)),
+			other: accessToken(t, "other", now.Add(-time.Minute)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSession(t)
+			refresh := s.pair(t, "alice").RefreshToken
+
+			body := `{"allDevices":` + strconv.FormatBool(tt.allDevices)
+			if tt.sendRefresh {
+				body += `,"refreshToken":"` + refresh + `"`
+			}
+			body += `}`
+
+			if got := s.serve(t, s.handler.Logout, tt.token, body); got != http.StatusOK {
+				t.Fatalf("status = %d, want %d", got, http.StatusOK)
+			}
+
+			if s.valid(tt.token) {
+				t.Error("logged out token is still valid")
+			}
+			if got := s.valid(tt.other); got != tt.wantOther {
+				t.Errorf("other token valid = %v, want %v", got, tt.wantOther)
+			}
+			if _, err := s.verifier.VerifyRefresh(context.Background(), refresh); tt.sendRefresh && err == nil {
+				t.Error("logged out refresh token is still valid")
+			}
+
+			// Logging in again right away works
+			if fresh := s.pair(t, "alice").AccessToken; !s.valid(fresh) {
+				t.Error("token issued after logout is rejected")
+			}
+		})
+	}
+}
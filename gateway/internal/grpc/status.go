@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BackendHealth reports the connection state of every backend. The gateway
+// keeps serving while a backend is down, so it reports "degraded" rather
+// than failing.
+func (h *GRPCProxyHandler) BackendHealth(c *gin.Context) {
+	backends := h.clients.BackendStatuses()
+
+	status := "healthy"
+	for _, backend := range backends {
+		if !backend.Ready {
+			status = "degraded"
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   status,
+		"backends": backends,
+	})
+}
+
+// CircuitBreakers reports the circuit breaker state of every backend.
+func (h *GRPCProxyHandler) CircuitBreakers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.clients.BreakerStatuses(),
+	})
+}
@@ -0,0 +1,463 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"api-gateway/internal/middleware"
+	"api-gateway/internal/ratelimit"
+)
+
+const (
+	// Default time allowed for a transcoded call.
+	defaultRouteTimeout = 30 * time.Second
+
+	// Maximum size of a transcoded request body.
+	maxRequestBodySize = 1 << 20
+)
+
+var errBodyTooLarge = errors.New("Request body is too large")
+
+// Route maps an HTTP endpoint onto a gRPC method. Fields are named by their
+// proto or JSON name. Path parameters and, unless the whole body is bound,
+// query parameters are bound to the request fields of the same name; unknown
+// query parameters are ignored.
+type Route struct {
+	// HTTP method and path, relative to the group the route is registered on.
+	Method string
+	Path   string
+
+	// Full gRPC method name, e.g. "contract.ContractService/GetContract".
+	RPC string
+
+	// Body is the request field decoded from the JSON body, "*" for the
+	// whole request message or empty when the route takes no body.
+	Body string
+
+	// UserField is the request field set to the authenticated user's ID,
+	// overriding any value sent by the client. Routes with a user field
+	// require authentication.
+	UserField string
+
+	// Defaults are applied to fields the request left unset.
+	Defaults map[string]string
+
+	// Statuses used when the response reports success or a business
+	// failure. Default to 200 and 400.
+	SuccessStatus int
+	FailureStatus int
+
+	// Timeout of the gRPC call. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// AttemptTimeout, if set, bounds each attempt of a retried Get* call
+	// instead of the AttemptTimeout of the backend's retry policy. Slow
+	// queries set it up to Timeout so they are not cut off and retried.
+	AttemptTimeout time.Duration
+
+	// RateLimit, if set, throttles the route per user or client IP on top
+	// of any limit of the group.
+	RateLimit *ratelimit.Policy
+
+	// Permission, if set, is required from the authenticated user.
+	Permission string
+
+	// Policy, if set, checks that the caller may make the decoded request,
+	// e.g. that they own the resource.
+	Policy Policy
+
+	// Audit, if set, records an audit event once the backend has
+	// answered with success.
+	Audit *AuditEvent
+}
+
+// AuditEvent describes the audit log entry created for a route.
+type AuditEvent struct {
+	Action   string
+	Resource string
+
+	// Fields are the request fields copied into the event metadata.
+	Fields []string
+}
+
+// boundRoute is a Route resolved against the proto registry.
+type boundRoute struct {
+	Route
+	fullMethod string
+	service    string
+	input      protoreflect.MessageType
+	output     protoreflect.MessageType
+	body       protoreflect.FieldDescriptor
+	userField  protoreflect.FieldDescriptor
+	defaults   map[protoreflect.FieldDescriptor]string
+	audit      []protoreflect.FieldDescriptor
+}
+
+// RegisterRoutes resolves each route's gRPC method and request fields and
+// registers a transcoding handler for it on the group.
+func (h *GRPCProxyHandler) RegisterRoutes(group gin.IRoutes, routes []Route) error {
+	for _, route := range routes {
+		bound, err := bindRoute(route)
+		if err != nil {
+			return fmt.Errorf("route %s %s: %w", route.Method, route.Path, err)
+		}
+		if _, ok := h.clients.conn(bound.service); !ok {
+			return fmt.Errorf("route %s %s: no client for service %s", route.Method, route.Path, bound.service)
+		}
+
+		var handlers []gin.HandlerFunc
+		if route.RateLimit != nil {
+			handlers = append(handlers, middleware.RateLimit(h.limits, *route.RateLimit))
+		}
+		if route.Permission != "" {
+			handlers = append(handlers, middleware.RequirePermission(route.Permission))
+		}
+		handlers = append(handlers, h.transcode(bound))
+		group.Handle(route.Method, route.Path, handlers...)
+	}
+	return nil
+}
+
+func bindRoute(route Route) (*boundRoute, error) {
+	service, method, ok := strings.Cut(route.RPC, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid RPC name %q", route.RPC)
+	}
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %s: %w", service, err)
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return nil, fmt.Errorf("unknown method %s", route.RPC)
+	}
+	if methodDesc.IsStreamingClient() || methodDesc.IsStreamingServer() {
+		return nil, fmt.Errorf("streaming method %s cannot be transcoded", route.RPC)
+	}
+
+	input, err := protoregistry.GlobalTypes.FindMessageByName(methodDesc.Input().FullName())
+	if err != nil {
+		return nil, err
+	}
+	output, err := protoregistry.GlobalTypes.FindMessageByName(methodDesc.Output().FullName())
+	if err != nil {
+		return nil, err
+	}
+
+	bound := &boundRoute{
+		Route:      route,
+		fullMethod: "/" + route.RPC,
+		service:    service,
+		input:      input,
+		output:     output,
+		defaults:   make(map[protoreflect.FieldDescriptor]string),
+	}
+	fields := input.Descriptor().Fields()
+
+	if route.Body != "" && route.Body != "*" {
+		if bound.body, err = lookupField(fields, route.Body); err != nil {
+			return nil, err
+		}
+		if bound.body.Message() == nil || bound.body.IsList() || bound.body.IsMap() {
+			return nil, fmt.Errorf("body field %s must be a message", route.Body)
+		}
+	}
+	if route.UserField != "" {
+		if bound.userField, err = lookupField(fields, route.UserField); err != nil {
+			return nil, err
+		}
+	}
+	for name, value := range route.Defaults {
+		fd, err := lookupField(fields, name)
+		if err != nil {
+			return nil, err
+		}
+		bound.defaults[fd] = value
+	}
+	if route.Audit != nil {
+		for _, name := range route.Audit.Fields {
+			fd, err := lookupField(fields, name)
+			if err != nil {
+				return nil, err
+			}
+			bound.audit = append(bound.audit, fd)
+		}
+	}
+
+	if bound.SuccessStatus == 0 {
+		bound.SuccessStatus = http.StatusOK
+	}
+	if bound.FailureStatus == 0 {
+		bound.FailureStatus = http.StatusBadRequest
+	}
+	if bound.Timeout == 0 {
+		bound.Timeout = defaultRouteTimeout
+	}
+
+	return bound, nil
+}
+
+func (h *GRPCProxyHandler) transcode(route *boundRoute) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userID")
+		if route.userField != nil && userID == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   "UNAUTHORIZED",
+				"message": "User not authenticated",
+			})
+			return
+		}
+
+		req := route.input.New()
+		if err := route.decode(c, req); errors.Is(err, errBodyTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"success": false,
+				"error":   "PAYLOAD_TOO_LARGE",
+				"message": err.Error(),
+			})
+			return
+		} else if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "INVALID_REQUEST",
+				"message": err.Error(),
+			})
+			return
+		}
+		if route.userField != nil {
+			req.Set(route.userField, protoreflect.ValueOfString(userID))
+		}
+
+		ctx, cancel := callContext(c, route.Timeout)
+		defer cancel()
+
+		if route.Policy != nil {
+			if err := route.Policy(ctx, c, h.clients, req.Interface()); err != nil {
+				var policyErr *PolicyError
+				if errors.As(err, &policyErr) {
+					requestLogger(ctx).Warn("Request rejected by policy",
+						zap.String("rpc", route.RPC),
+						zap.String("userID", userID),
+						zap.String("reason", policyErr.Message),
+					)
+					c.JSON(policyErr.Status, gin.H{
+						"success": false,
+						"error":   policyErr.Code,
+						"message": policyErr.Message,
+					})
+					return
+				}
+				respondWithError(c, route.RPC, err)
+				return
+			}
+		}
+
+		conn, _ := h.clients.conn(route.service)
+
+		var opts []grpc.CallOption
+		if route.AttemptTimeout > 0 {
+			opts = append(opts, WithAttemptTimeout(route.AttemptTimeout))
+		}
+
+		resp := route.output.New().Interface()
+		if err := conn.Invoke(ctx, route.fullMethod, req.Interface(), resp, opts...); err != nil {
+			respondWithError(c, route.RPC, err)
+			return
+		}
+
+		succeeded := true
+		if r, ok := resp.(result); ok {
+			respond(c, route.SuccessStatus, route.FailureStatus, r)
+			succeeded = r.GetSuccess()
+		} else {
+			c.JSON(route.SuccessStatus, resp)
+		}
+
+		if route.Audit != nil && succeeded {
+			go h.clients.logAuditEvent(context.WithoutCancel(ctx), userID, route.Audit.Action, route.Audit.Resource,
+				route.auditMetadata(req), c.ClientIP(), c.Request.UserAgent())
+		}
+	}
+}
+
+// decode fills the request message from the body, then the query string,
+// then the path parameters, each overriding the previous ones. Defaults only
+// fill fields left unset.
+func (r *boundRoute) decode(c *gin.Context, req protoreflect.Message) error {
+	fields := req.Descriptor().Fields()
+
+	if r.Body != "" {
+		data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodySize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return errBodyTooLarge
+		}
+		if err != nil {
+			return errors.New("Invalid request body")
+		}
+
+		var target proto.Message = req.Interface()
+		if r.body != nil {
+			target = req.Mutable(r.body).Message().Interface()
+		}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, target); err != nil {
+			return errors.New("Invalid request body")
+		}
+	}
+
+	if r.Body != "*" {
+		for key, values := range c.Request.URL.Query() {
+			fd, err := lookupField(fields, key)
+			if err != nil || fd.Message() != nil {
+				continue
+			}
+			for _, value := range values {
+				if err := setField(req, fd, value); err != nil {
+					return fmt.Errorf("Invalid value for query parameter %s", key)
+				}
+			}
+		}
+	}
+
+	for _, param := range c.Params {
+		fd, err := lookupField(fields, param.Key)
+		if err != nil {
+			continue
+		}
+		req.Clear(fd)
+		if err := setField(req, fd, param.Value); err != nil {
+			return fmt.Errorf("Invalid value for path parameter %s", param.Key)
+		}
+	}
+
+	for fd, value := range r.defaults {
+		if req.Has(fd) {
+			continue
+		}
+		if err := setField(req, fd, value); err != nil {
+			return fmt.Errorf("Invalid default for %s", fd.Name())
+		}
+	}
+
+	return nil
+}
+
+func (r *boundRoute) auditMetadata(req protoreflect.Message) map[string]string {
+	metadata := make(map[string]string, len(r.audit))
+	for _, fd := range r.audit {
+		value := req.Get(fd)
+		switch fd.Kind() {
+		case protoreflect.DoubleKind, protoreflect.FloatKind:
+			metadata[fd.JSONName()] = strconv.FormatFloat(value.Float(), 'f', 2, 64)
+		default:
+			metadata[fd.JSONName()] = value.String()
+		}
+	}
+	return metadata
+}
+
+func lookupField(fields protoreflect.FieldDescriptors, name string) (protoreflect.FieldDescriptor, error) {
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd, nil
+	}
+	if fd := fields.ByJSONName(name); fd != nil {
+		return fd, nil
+	}
+	return nil, fmt.Errorf("unknown field %s", name)
+}
+
+// setField parses a string into a scalar field, appending to repeated ones.
+func setField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, value string) error {
+	if fd.IsMap() || fd.Message() != nil {
+		return fmt.Errorf("field %s cannot be set from a string", fd.Name())
+	}
+
+	var v protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(value)
+	case protoreflect.BytesKind:
+		v = protoreflect.ValueOfBytes([]byte(value))
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfUint64(n)
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfFloat32(float32(f))
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfFloat64(f)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(value)); ev != nil {
+			v = protoreflect.ValueOfEnum(ev.Number())
+			break
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		v = protoreflect.ValueOfEnum(protoreflect.EnumNumber(n))
+	default:
+		return fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+
+	if fd.IsList() {
+		msg.Mutable(fd).List().Append(v)
+		return nil
+	}
+	msg.Set(fd, v)
+	return nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"api-gateway/internal/auth"
+	"api-gateway/shared/jwtauth"
+	"api-gateway/shared/middleware"
+)
+
+// TokenHandler validates tokens with the same verifier as the auth
+// middleware, without a round trip to the auth service.
+type TokenHandler struct {
+	verifier *auth.Verifier
+}
+
+func NewTokenHandler(verifier *auth.Verifier) *TokenHandler {
+	return &TokenHandler{
+		verifier: verifier,
+	}
+}
+
+func (h *TokenHandler) ValidateToken(c *gin.Context) {
+	token, ok := jwtauth.BearerToken(c.GetHeader("Authorization"))
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "UNAUTHORIZED",
+			"message": "No token provided",
+		})
+		return
+	}
+
+	claims, err := h.verifier.Verify(c.Request.Context(), token)
+	if err != nil {
+		middleware.RequestLogger(c).Debug("Token validation failed", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"valid": false,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"valid":       true,
+		"userId":      claims.UserID,
+		"email":       claims.Email,
+		"roles":       claims.Roles,
+		"permissions": claims.Permissions,
+	})
+}
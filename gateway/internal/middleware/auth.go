@@ -2,59 +2,54 @@ package middleware
 
 import (
 	"net/http"
-	"os"
-	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
-)
 
-type Claims struct {
-	UserID string `json:"sub"`
-	Email  string `json:"email"`
-	jwt.RegisteredClaims
-}
+	"api-gateway/internal/auth"
+	"api-gateway/shared/jwtauth"
+	sharedMiddleware "api-gateway/shared/middleware"
+)
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware requires a bearer token accepted by verifier and stores the
+// caller's identity in the context.
+func AuthMiddleware(verifier *auth.Verifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+
+		var tokenString string
+		var ok bool
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "UNAUTHORIZED",
-				"message": "Authorization header is required",
-			})
-			c.Abort()
-			return
+			// Browsers cannot set headers on WebSocket upgrades, so the token
+			// may be offered as the subprotocol following "bearer"
+			tokenString, ok = websocketBearerToken(c.Request)
+		} else {
+			tokenString, ok = jwtauth.BearerToken(authHeader)
+			if !ok {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"success": false,
+					"error":   "UNAUTHORIZED",
+					"message": "Invalid authorization header format",
+				})
+				c.Abort()
+				return
+			}
 		}
 
-		// Check if header starts with "Bearer "
-		const bearerPrefix = "Bearer "
-		if !strings.HasPrefix(authHeader, bearerPrefix) {
+		if !ok {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"success": false,
 				"error":   "UNAUTHORIZED",
-				"message": "Invalid authorization header format",
+				"message": "Authorization header is required",
 			})
 			c.Abort()
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, bearerPrefix)
-
-		// Parse and validate JWT token
-		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			// Verify signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(getJWTSecret()), nil
-		})
-
+		claims, err := verifier.Verify(c.Request.Context(), tokenString)
 		if err != nil {
-			zap.L().Warn("JWT validation failed", zap.Error(err))
+			sharedMiddleware.RequestLogger(c).Warn("JWT validation failed", zap.Error(err))
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"success": false,
 				"error":   "UNAUTHORIZED",
@@ -64,29 +59,28 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		if !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "UNAUTHORIZED",
-				"message": "Invalid token",
-			})
-			c.Abort()
-			return
-		}
-
 		// Set user information in context
 		c.Set("userID", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("roles", claims.Roles)
+		c.Set("permissions", claims.Permissions)
+		c.Set("token", tokenString)
+		c.Set("claims", claims)
 
 		c.Next()
 	}
 }
 
-func getJWTSecret() string {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "your-secret-key" // Default for development
+func websocketBearerToken(r *http.Request) (string, bool) {
+	if !websocket.IsWebSocketUpgrade(r) {
+		return "", false
 	}
-	return secret
-}
 
+	protocols := websocket.Subprotocols(r)
+	for i, protocol := range protocols {
+		if protocol == "bearer" && i+1 < len(protocols) {
+			return protocols[i+1], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	sharedMiddleware "api-gateway/shared/middleware"
+)
+
+// Permissions required by route policies.
+const (
+	PermissionAuditRead      = "audit:read"
+	PermissionDisputeResolve = "dispute:resolve"
+	PermissionWalletWrite    = "wallet:write"
+	PermissionPaymentRead    = "payment:read"
+	PermissionUserManage     = "user:manage"
+	PermissionGatewayAdmin   = "gateway:admin"
+)
+
+// Roles known to the gateway.
+const (
+	RoleAdmin    = "admin"
+	RoleAuditor  = "auditor"
+	RoleMediator = "mediator"
+)
+
+// rolePermissions lists the permissions granted by each role on top of the
+// permissions claim of the token. "*" grants every permission and "kind:*"
+// every permission of that kind.
+var rolePermissions = map[string][]string{
+	RoleAdmin:    {"*"},
+	RoleAuditor:  {PermissionAuditRead},
+	RoleMediator: {PermissionDisputeResolve},
+}
+
+// RequirePermission rejects requests whose token does not grant permission.
+// It must run after AuthMiddleware.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			sharedMiddleware.RequestLogger(c).Warn("Permission denied",
+				zap.String("userID", c.GetString("userID")),
+				zap.String("permission", permission),
+				zap.String("path", c.FullPath()),
+			)
+			c.JSON(http.StatusForbidden, gin.H{
+				"success":    false,
+				"error":      "FORBIDDEN",
+				"message":    "Permission " + permission + " is required",
+				"permission": permission,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// HasPermission reports whether the authenticated user's roles or
+// permissions claim grant permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	for _, granted := range c.GetStringSlice("permissions") {
+		if grants(granted, permission) {
+			return true
+		}
+	}
+
+	for _, role := range c.GetStringSlice("roles") {
+		for _, granted := range rolePermissions[role] {
+			if grants(granted, permission) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func grants(granted, permission string) bool {
+	if granted == "*" || granted == permission {
+		return true
+	}
+
+	kind, ok := strings.CutSuffix(granted, ":*")
+	return ok && strings.HasPrefix(permission, kind+":")
+}
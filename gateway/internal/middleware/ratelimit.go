@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"api-gateway/internal/ratelimit"
+	sharedMiddleware "api-gateway/shared/middleware"
+)
+
+// RateLimit throttles requests with a token bucket per caller: the
+// authenticated user, or the client IP on routes without authentication. It
+// sets the X-RateLimit-* headers and rejects requests over the limit with
+// 429 and Retry-After. If the store fails, requests are let through.
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := policy.Name + ":ip:" + c.ClientIP()
+		if userID := c.GetString("userID"); userID != "" {
+			key = policy.Name + ":user:" + userID
+		}
+
+		result, err := store.Allow(c.Request.Context(), key, policy)
+		if err != nil {
+			sharedMiddleware.RequestLogger(c).Error("Rate limit check failed",
+				zap.String("policy", policy.Name),
+				zap.Error(err),
+			)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			sharedMiddleware.RequestLogger(c).Warn("Rate limit exceeded",
+				zap.String("policy", policy.Name),
+				zap.String("key", key),
+				zap.String("path", c.FullPath()),
+			)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"success":    false,
+				"error":      "TOO_MANY_REQUESTS",
+				"message":    "Rate limit exceeded, retry in " + strconv.Itoa(retryAfter) + "s",
+				"retryAfter": retryAfter,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
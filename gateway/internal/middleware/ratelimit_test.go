@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"api-gateway/internal/ratelimit"
+)
+
+// failingStore fails every check.
+type failingStore struct{}
+
+func (failingStore) Allow(context.Context, string, ratelimit.Policy) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func (failingStore) Close() error { return nil }
+
+func TestRateLimit(t *testing.T) {
+	policy := ratelimit.Policy{Name: "test", Requests: 1, Period: time.Hour}
+
+	type request struct {
+		ip     string
+		userID string
+	}
+
+	tests := []struct {
+		name       string
+		store      ratelimit.Store
+		requests   []request
+		wantStatus int
+	}{
+		{
+			name:       "first request",
+			store:      ratelimit.NewMemoryStore(),
+			requests:   []request{{ip: "192.0.2.1"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "same IP",
+			store:      ratelimit.NewMemoryStore(),
+			requests:   []request{{ip: "192.0.2.1"}, {ip: "192.0.2.1"}},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "other IP",
+			store:      ratelimit.NewMemoryStore(),
+			requests:   []request{{ip: "192.0.2.1"}, {ip: "192.0.2.2"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "same user from another IP",
+			store:      ratelimit.NewMemoryStore(),
+			requests:   []request{{ip: "192.0.2.1", userID: "alice"}, {ip: "192.0.2.2", userID: "alice"}},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "other user from the same IP",
+			store:      ratelimit.NewMemoryStore(),
+			requests:   []request{{ip: "192.0.2.1", userID: "alice"}, {ip: "192.0.2.1", userID: "bob"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "store failure lets requests through",
+			store:      failingStore{},
+			requests:   []request{{ip: "192.0.2.1"}, {ip: "192.0.2.1"}},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := RateLimit(tt.store, policy)
+
+			var w *httptest.ResponseRecorder
+			for _, req := range tt.requests {
+				w = httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+				c.Request.RemoteAddr = req.ip + ":1234"
+				if req.userID != "" {
+					c.Set("userID", req.userID)
+				}
+
+				limit(c)
+				if !c.IsAborted() {
+					c.Status(http.StatusOK)
+				}
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+				t.Error("429 without Retry-After")
+			}
+		})
+	}
+}
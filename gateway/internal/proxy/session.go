@@ -0,0 +1,61 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"api-gateway/internal/auth"
+	"api-gateway/shared/jwtauth"
+)
+
+// Time between two revocation checks of a relayed connection's token.
+const sessionCheckInterval = 30 * time.Second
+
+var (
+	errSessionExpired = errors.New("session expired")
+	errSessionRevoked = errors.New("session revoked")
+)
+
+// watchSession returns a context that is canceled when the token of the
+// request expires or is revoked, so relayed connections end with the session
+// they were opened under. Revocation is checked every sessionCheckInterval;
+// context.Cause tells why the context ended.
+func watchSession(c *gin.Context, verifier *auth.Verifier) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(c.Request.Context())
+
+	stopExpiry := func() bool { return false }
+	if claims, ok := c.MustGet("claims").(*auth.Claims); ok && claims.ExpiresAt != nil {
+		expiry := time.AfterFunc(time.Until(claims.ExpiresAt.Time), func() {
+			cancel(errSessionExpired)
+		})
+		stopExpiry = expiry.Stop
+	}
+
+	token := c.GetString("token")
+	go func() {
+		ticker := time.NewTicker(sessionCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Other errors, such as Redis being unreachable, do not end
+				// the session
+				if _, err := verifier.Verify(ctx, token); errors.Is(err, jwtauth.ErrTokenRevoked) {
+					cancel(errSessionRevoked)
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() {
+		stopExpiry()
+		cancel(context.Canceled)
+	}
+}
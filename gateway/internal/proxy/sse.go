@@ -0,0 +1,152 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"api-gateway/internal/auth"
+	"api-gateway/shared/middleware"
+)
+
+// Query parameters forwarded to the notification service's event stream.
+var forwardedSSEParams = []string{"clientId", "lastSeq", "lastId", "topics"}
+
+// SSEProxy relays the notification service's Server-Sent Events stream to
+// clients whose network does not allow WebSocket upgrades.
+type SSEProxy struct {
+	targetURL  string
+	verifier   *auth.Verifier
+	httpClient *http.Client
+}
+
+// NewSSEProxy relays to targetURL. verifier re-checks the token of open
+// streams, which are closed once it expires or is revoked.
+func NewSSEProxy(targetURL string, verifier *auth.Verifier) *SSEProxy {
+	return &SSEProxy{
+		targetURL: targetURL,
+		verifier:  verifier,
+		// No overall timeout: the stream stays open until either side
+		// disconnects
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:                 http.ProxyFromEnvironment,
+				ResponseHeaderTimeout: 10 * time.Second,
+			},
+		},
+	}
+}
+
+// ServeSSE must run behind the auth middleware, which provides the token
+// forwarded to the notification service.
+func (p *SSEProxy) ServeSSE(c *gin.Context) {
+	token := c.GetString("token")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "UNAUTHORIZED",
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	target, err := url.Parse(p.targetURL)
+	if err != nil {
+		middleware.RequestLogger(c).Error("Invalid notification SSE URL", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "INTERNAL_SERVER_ERROR",
+			"message": "Failed to create proxy request",
+		})
+		return
+	}
+
+	query := c.Request.URL.Query()
+	forwarded := url.Values{}
+	for _, key := range forwardedSSEParams {
+		if value := query.Get(key); value != "" {
+			forwarded.Set(key, value)
+		}
+	}
+	target.RawQuery = forwarded.Encode()
+
+	// The stream ends when the token expires or is revoked
+	session, endSession := watchSession(c, p.verifier)
+	defer endSession()
+
+	req, err := http.NewRequestWithContext(session, http.MethodGet, target.String(), nil)
+	if err != nil {
+		middleware.RequestLogger(c).Error("Failed to create proxy request", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "INTERNAL_SERVER_ERROR",
+			"message": "Failed to create proxy request",
+		})
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set(middleware.RequestIDHeader, c.GetString(middleware.RequestIDKey))
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		middleware.RequestLogger(c).Error("Failed to connect to notification event stream", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{
+			"success": false,
+			"error":   "BAD_GATEWAY",
+			"message": "Service unavailable",
+		})
+		return
+	}
+	defer resp.Body.Close()
+
+	// The stream outlives the server's write timeout
+	controller := http.NewResponseController(c.Writer)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		middleware.RequestLogger(c).Warn("Failed to clear write deadline", zap.Error(err))
+	}
+
+	// Only the stream headers are copied; CORS is handled by the gateway
+	for _, key := range []string{"Content-Type", "Cache-Control"} {
+		if value := resp.Header.Get(key); value != "" {
+			c.Header(key, value)
+		}
+	}
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(resp.StatusCode)
+	c.Writer.Flush()
+
+	middleware.RequestLogger(c).Info("SSE proxy connected",
+		zap.String("userID", c.GetString("userID")),
+		zap.String("target", p.targetURL),
+	)
+
+	// Flush after every read so events reach the client as they arrive
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := c.Writer.Write(buf[:n]); err != nil {
+				break
+			}
+			c.Writer.Flush()
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	middleware.RequestLogger(c).Info("SSE proxy disconnected",
+		zap.String("userID", c.GetString("userID")),
+		zap.NamedError("reason", context.Cause(session)),
+	)
+}
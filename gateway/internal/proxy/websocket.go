@@ -0,0 +1,259 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"api-gateway/internal/auth"
+	"api-gateway/shared/middleware"
+)
+
+const (
+	// Time allowed to write a message to either peer.
+	wsWriteWait = 10 * time.Second
+
+	// Time allowed to complete the handshake with the notification service.
+	wsDialTimeout = 10 * time.Second
+
+	// Time allowed to read the next pong message from the client.
+	wsPongWait = 60 * time.Second
+
+	// Send pings to the client with this period. Must be less than
+	// wsPongWait.
+	wsPingPeriod = (wsPongWait * 9) / 10
+
+	// Subprotocol browsers use to offer their bearer token.
+	bearerSubprotocol = "bearer"
+)
+
+// Query parameters forwarded to the notification service on connect.
+var forwardedWSParams = []string{"clientId", "lastSeq", "lastId"}
+
+// WebSocketProxy relays an authenticated client WebSocket to the
+// notification service so browsers only ever talk to the gateway.
+type WebSocketProxy struct {
+	targetURL string
+	verifier  *auth.Verifier
+	upgrader  websocket.Upgrader
+	dialer    *websocket.Dialer
+}
+
+// NewWebSocketProxy relays to targetURL. verifier re-checks the token of
+// open connections, which are closed once it expires or is revoked.
+func NewWebSocketProxy(targetURL string, allowedOrigins []string, verifier *auth.Verifier) *WebSocketProxy {
+	return &WebSocketProxy{
+		targetURL: targetURL,
+		verifier:  verifier,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			Subprotocols:    []string{bearerSubprotocol},
+			CheckOrigin:     newOriginChecker(allowedOrigins),
+		},
+		dialer: &websocket.Dialer{
+			HandshakeTimeout: wsDialTimeout,
+		},
+	}
+}
+
+// ServeWS must run behind the auth middleware, which provides the token
+// forwarded to the notification service.
+func (p *WebSocketProxy) ServeWS(c *gin.Context) {
+	token := c.GetString("token")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "UNAUTHORIZED",
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "INVALID_REQUEST",
+			"message": "WebSocket upgrade required",
+		})
+		return
+	}
+
+	// Connect to the notification service first so failures can still be
+	// reported as a regular HTTP response
+	backendURL, err := p.backendURL(c.Request.URL.Query())
+	if err != nil {
+		middleware.RequestLogger(c).Error("Invalid notification WebSocket URL", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "INTERNAL_SERVER_ERROR",
+			"message": "Failed to create proxy request",
+		})
+		return
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	header.Set(middleware.RequestIDHeader, c.GetString(middleware.RequestIDKey))
+
+	backend, _, err := p.dialer.DialContext(c.Request.Context(), backendURL, header)
+	if err != nil {
+		middleware.RequestLogger(c).Error("Failed to connect to notification WebSocket", zap.Error(err))
+		c.JSON(http.StatusBadGateway, gin.H{
+			"success": false,
+			"error":   "BAD_GATEWAY",
+			"message": "Service unavailable",
+		})
+		return
+	}
+
+	client, err := p.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		middleware.RequestLogger(c).Error("Failed to upgrade connection", zap.Error(err))
+		backend.Close()
+		return
+	}
+
+	middleware.RequestLogger(c).Info("WebSocket proxy connected",
+		zap.String("userID", c.GetString("userID")),
+		zap.String("target", p.targetURL),
+	)
+
+	// A client that stops answering pings is dropped, so a vanished peer
+	// cannot keep the relay and its backend connection open
+	client.SetReadDeadline(time.Now().Add(wsPongWait))
+	client.SetPongHandler(func(string) error {
+		client.SetReadDeadline(time.Now().Add(wsPongWait))
+		return nil
+	})
+
+	session, endSession := watchSession(c, p.verifier)
+	defer endSession()
+
+	done := make(chan struct{})
+	errc := make(chan error, 3)
+	go relay(backend, client, errc)
+	go relay(client, backend, errc)
+	go ping(client, done, errc)
+
+	// Either side going away ends the session for both, and so does the
+	// token expiring or being revoked
+	select {
+	case err = <-errc:
+	case <-session.Done():
+		err = context.Cause(session)
+		closeMessage := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error())
+		client.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(wsWriteWait))
+	}
+	close(done)
+	client.Close()
+	backend.Close()
+
+	middleware.RequestLogger(c).Info("WebSocket proxy disconnected",
+		zap.String("userID", c.GetString("userID")),
+		zap.NamedError("reason", err),
+	)
+}
+
+func (p *WebSocketProxy) backendURL(query url.Values) (string, error) {
+	target, err := url.Parse(p.targetURL)
+	if err != nil {
+		return "", err
+	}
+
+	forwarded := url.Values{}
+	for _, key := range forwardedWSParams {
+		if value := query.Get(key); value != "" {
+			forwarded.Set(key, value)
+		}
+	}
+	target.RawQuery = forwarded.Encode()
+
+	return target.String(), nil
+}
+
+// relay copies messages from src to dst until src fails, then forwards the
+// close reason to dst.
+func relay(dst, src *websocket.Conn, errc chan<- error) {
+	for {
+		messageType, message, err := src.ReadMessage()
+		if err != nil {
+			dst.WriteControl(websocket.CloseMessage, closeMessageFor(err), time.Now().Add(wsWriteWait))
+			errc <- err
+			return
+		}
+
+		dst.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		if err := dst.WriteMessage(messageType, message); err != nil {
+			errc <- err
+			return
+		}
+	}
+}
+
+// ping keeps the client connection alive until done is closed.
+// WriteControl may run concurrently with the relay writing to conn.
+func ping(conn *websocket.Conn, done <-chan struct{}, errc chan<- error) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}
+}
+
+func closeMessageFor(err error) []byte {
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		switch closeErr.Code {
+		case websocket.CloseNoStatusReceived, websocket.CloseAbnormalClosure, websocket.CloseTLSHandshake:
+			// Reserved codes that must not be sent on the wire
+		default:
+			return websocket.FormatCloseMessage(closeErr.Code, closeErr.Text)
+		}
+	}
+	return websocket.FormatCloseMessage(websocket.CloseGoingAway, "")
+}
+
+// newOriginChecker builds the upgrader's CheckOrigin from an allowlist.
+// An empty allowlist keeps gorilla's same-origin check and "*" allows any
+// origin.
+func newOriginChecker(allowedOrigins []string) func(r *http.Request) bool {
+	if len(allowedOrigins) == 0 {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimRight(origin, "/"))] = true
+	}
+
+	return func(r *http.Request) bool {
+		if allowed["*"] {
+			return true
+		}
+
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			// Non-browser clients do not send an Origin header
+			return true
+		}
+
+		return allowed[strings.ToLower(origin)]
+	}
+}
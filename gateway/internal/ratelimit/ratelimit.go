@@ -0,0 +1,144 @@
+// Package ratelimit implements token bucket rate limiting with in-memory and
+// Redis-backed stores.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Policy allows Requests per Period, with bursts of up to Burst requests.
+type Policy struct {
+	// Name identifies the policy in the keys of its buckets.
+	Name string
+
+	Requests int
+	Period   time.Duration
+
+	// Burst is the bucket capacity. Defaults to Requests.
+	Burst int
+}
+
+func (p Policy) capacity() float64 {
+	if p.Burst > 0 {
+		return float64(p.Burst)
+	}
+	return float64(p.Requests)
+}
+
+// rate is the number of tokens added per second.
+func (p Policy) rate() float64 {
+	return float64(p.Requests) / p.Period.Seconds()
+}
+
+// Result describes the state of a bucket after a request.
+type Result struct {
+	Allowed bool
+
+	// Limit is the bucket capacity and Remaining the requests left in it.
+	Limit     int
+	Remaining int
+
+	// RetryAfter is the wait until the next request is allowed, zero if
+	// the request was allowed. Reset is the wait until the bucket is full.
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// Store takes tokens from the buckets of a policy.
+type Store interface {
+	// Allow takes a token from the bucket identified by key.
+	Allow(ctx context.Context, key string, policy Policy) (Result, error)
+
+	// Close releases the connections of the store.
+	Close() error
+}
+
+// result derives the Result of a request from the tokens left in the bucket.
+func result(policy Policy, allowed bool, tokens float64) Result {
+	capacity := policy.capacity()
+	rate := policy.rate()
+
+	res := Result{
+		Allowed:   allowed,
+		Limit:     int(capacity),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((capacity - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		res.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return res
+}
+
+// Interval between sweeps of the in-memory buckets that have refilled.
+const memorySweepInterval = time.Minute
+
+// MemoryStore keeps buckets in process memory, so limits apply per gateway
+// instance. It suits single-node deployments.
+type MemoryStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+
+	// fullAt is when the bucket will have refilled, after which it can be
+	// dropped.
+	fullAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryStore) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		s.sweep(now)
+	}
+
+	capacity := policy.capacity()
+	rate := policy.rate()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.fullAt = now.Add(time.Duration((capacity - b.tokens) / rate * float64(time.Second)))
+
+	return result(policy, allowed, b.tokens), nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.After(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
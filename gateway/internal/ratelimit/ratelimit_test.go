@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreAllow(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		// requests are made back to back, pausing for the given time first
+		pauses        []time.Duration
+		wantAllowed   []bool
+		wantRemaining int
+	}{
+		{
+			name:          "within the limit",
+			policy:        Policy{Name: "p", Requests: 3, Period: time.Hour},
+			pauses:        []time.Duration{0, 0, 0},
+			wantAllowed:   []bool{true, true, true},
+			wantRemaining: 0,
+		},
+		{
+			name:          "over the limit",
+			policy:        Policy{Name: "p", Requests: 2, Period: time.Hour},
+			pauses:        []time.Duration{0, 0, 0},
+			wantAllowed:   []bool{true, true, false},
+			wantRemaining: 0,
+		},
+		{
+			name:          "burst below the rate",
+			policy:        Policy{Name: "p", Requests: 10, Period: time.Hour, Burst: 1},
+			pauses:        []time.Duration{0, 0},
+			wantAllowed:   []bool{true, false},
+			wantRemaining: 0,
+		},
+		{
+			name:          "bucket refills",
+			policy:        Policy{Name: "p", Requests: 1, Period: 50 * time.Millisecond},
+			pauses:        []time.Duration{0, 0, 60 * time.Millisecond},
+			wantAllowed:   []bool{true, false, true},
+			wantRemaining: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewMemoryStore()
+
+			var res Result
+			for i, pause := range tt.pauses {
+				time.Sleep(pause)
+
+				var err error
+				res, err = s.Allow(context.Background(), "key", tt.policy)
+				if err != nil {
+					t.Fatalf("Allow: %v", err)
+				}
+				if res.Allowed != tt.wantAllowed[i] {
+					t.Errorf("request %d: Allowed = %v, want %v", i, res.Allowed, tt.wantAllowed[i])
+				}
+				if res.Allowed && res.RetryAfter != 0 {
+					t.Errorf("request %d: RetryAfter = %v on an allowed request", i, res.RetryAfter)
+				}
+				if !res.Allowed && res.RetryAfter <= 0 {
+					t.Errorf("request %d: RetryAfter = %v on a rejected request", i, res.RetryAfter)
+				}
+			}
+
+			if res.Limit != int(tt.policy.capacity()) || res.Remaining != tt.wantRemaining {
+				t.Errorf("Limit, Remaining = %d, %d, want %d, %d", res.Limit, res.Remaining, int(tt.policy.capacity()), tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	s := NewMemoryStore()
+	policy := Policy{Name: "p", Requests: 1, Period: time.Hour}
+
+	for _, key := range []string{"a", "b"} {
+		res, err := s.Allow(context.Background(), key, policy)
+		if err != nil {
+			t.Fatalf("Allow(%s): %v", key, err)
+		}
+		if !res.Allowed {
+			t.Errorf("Allow(%s) rejected the first request of its bucket", key)
+		}
+	}
+}
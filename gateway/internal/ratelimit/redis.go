@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// takeTokenScript refills and takes a token from the bucket in KEYS[1]
+// atomically, using the Redis clock so every gateway instance agrees.
+// ARGV holds the capacity and the refill rate in tokens per millisecond. It
+// returns whether the token was taken and the tokens left.
+var takeTokenScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1]) or capacity
+local updated = tonumber(state[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+// Prefix of the bucket keys.
+const redisKeyPrefix = "ratelimit:"
+
+// RedisStore keeps buckets in Redis so limits hold across gateway instances.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(redisURL string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	zap.L().Info("Connected to Redis rate limit store")
+
+	return &RedisStore{client: client}, nil
+}
+
+func (s *RedisStore) Allow(ctx context.Context, key string, policy Policy) (Result, error) {
+	rate := policy.rate() / 1000
+
+	values, err := takeTokenScript.Run(ctx, s.client, []string{redisKeyPrefix + key},
+		policy.capacity(), rate).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script result %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	tokensValue, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensValue, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid rate limit tokens %q", tokensValue)
+	}
+
+	return result(policy, allowed == 1, tokens), nil
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
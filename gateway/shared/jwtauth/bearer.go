@@ -0,0 +1,20 @@
+package jwtauth
+
+import "strings"
+
+const bearerScheme = "Bearer"
+
+// BearerToken extracts the token from an Authorization header value. The
+// scheme is matched case-insensitively.
+func BearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", false
+	}
+	return token, true
+}
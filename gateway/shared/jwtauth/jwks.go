@@ -0,0 +1,307 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// Minimum time between two refreshes triggered by unknown key IDs, so
+	// tokens with made-up kids cannot hammer the key endpoint.
+	minRefreshInterval = 30 * time.Second
+
+	// Time allowed to fetch the JWKS document.
+	fetchTimeout = 10 * time.Second
+
+	// DefaultRefreshInterval is a sensible period for Run.
+	DefaultRefreshInterval = 5 * time.Minute
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// JWKS is a cached set of public signing keys loaded from a JSON Web Key Set
+// document. Several keys can be active at once so tokens signed with the
+// previous key keep verifying during a rotation.
+type JWKS struct {
+	source      string
+	fetch       func(ctx context.Context) ([]byte, error)
+	group       singleflight.Group
+	mutex       sync.RWMutex
+	keys        map[string]any
+	lastRefresh time.Time
+}
+
+// NewJWKSFromFile loads a key set from a local file, which is re-read when a
+// token names a key it does not contain.
+func NewJWKSFromFile(path string) (*JWKS, error) {
+	return newJWKS(path, func(ctx context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	})
+}
+
+// NewJWKSFromURL loads a key set from a URL, which is fetched again when a
+// token names a key it does not contain.
+func NewJWKSFromURL(url string) (*JWKS, error) {
+	client := &http.Client{Timeout: fetchTimeout}
+
+	return newJWKS(url, func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	})
+}
+
+func newJWKS(source string, fetch func(ctx context.Context) ([]byte, error)) (*JWKS, error) {
+	s := &JWKS{
+		source: source,
+		fetch:  fetch,
+	}
+
+	if err := s.refresh(context.Background(), 0); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Run reloads the key set every interval until ctx is done, so keys added
+// to the set are known before tokens use them and removed keys stop
+// verifying. A failed reload keeps the current keys.
+func (s *JWKS) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.refresh(ctx, 0); err != nil {
+				zap.L().Error("Failed to refresh JWKS", zap.String("source", s.source), zap.Error(err))
+			}
+		}
+	}
+}
+
+// Key returns the key with the given ID, reloading the key set once if it is
+// unknown.
+func (s *JWKS) Key(kid string) (any, error) {
+	s.mutex.RLock()
+	key, ok := s.keys[kid]
+	stale := time.Since(s.lastRefresh) >= minRefreshInterval
+	s.mutex.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, ErrUnknownKey
+	}
+
+	if err := s.refresh(context.Background(), minRefreshInterval); err != nil {
+		zap.L().Error("Failed to refresh JWKS", zap.String("source", s.source), zap.Error(err))
+		return nil, ErrUnknownKey
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// Keys returns every key in the set, for tokens that do not name one.
+func (s *JWKS) Keys() []any {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	keys := make([]any, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// refresh reloads the key set unless it was loaded less than minAge ago.
+// Concurrent callers share a single fetch, which runs without holding the
+// lock so tokens keep verifying against the current keys meanwhile.
+func (s *JWKS) refresh(ctx context.Context, minAge time.Duration) error {
+	_, err, _ := s.group.Do("refresh", func() (any, error) {
+		s.mutex.Lock()
+		// Another request may have refreshed while this one waited
+		if !s.lastRefresh.IsZero() && time.Since(s.lastRefresh) < minAge {
+			s.mutex.Unlock()
+			return nil, nil
+		}
+		s.lastRefresh = time.Now()
+		s.mutex.Unlock()
+
+		keys, err := s.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mutex.Lock()
+		s.keys = keys
+		s.mutex.Unlock()
+
+		zap.L().Info("Loaded JWKS", zap.String("source", s.source), zap.Int("keys", len(keys)))
+		return nil, nil
+	})
+	return err
+}
+
+// load fetches and parses the key set. Unusable keys are logged and
+// skipped, so one bad entry does not take the others down with it.
+func (s *JWKS) load(ctx context.Context) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWKS from %s: %w", s.source, err)
+	}
+
+	keys, invalid, err := parseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWKS from %s: %w", s.source, err)
+	}
+	for _, err := range invalid {
+		zap.L().Warn("Skipping invalid JWKS key", zap.String("source", s.source), zap.Error(err))
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS from %s contains no usable keys", s.source)
+	}
+
+	return keys, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the RSA and EC signing keys of a key set by key ID,
+// along with an error for each such key that could not be decoded. Keys of
+// other types or meant for encryption are ignored.
+func parseJWKS(data []byte) (map[string]any, []error, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	var invalid []error
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key any
+		var err error
+		switch jwk.Kty {
+		case "RSA":
+			key, err = jwk.rsaPublicKey()
+		case "EC":
+			key, err = jwk.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			invalid = append(invalid, fmt.Errorf("key %q: %w", jwk.Kid, err))
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	return keys, invalid, nil
+}
+
+func (k *jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil || !e.IsInt64() || e.Int64() < 3 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k *jsonWebKey) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+
+	key := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	if _, err := key.ECDH(); err != nil {
+		return nil, errors.New("point is not on the curve")
+	}
+	return key, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,269 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// keyServer serves a key set that tests can swap, counting the fetches.
+type keyServer struct {
+	*httptest.Server
+	mutex   sync.Mutex
+	data    []byte
+	fetches atomic.Int32
+
+	// block, if set, holds every fetch until it is closed.
+	block chan struct{}
+}
+
+func newKeyServer(t *testing.T, data []byte) *keyServer {
+	t.Helper()
+
+	s := &keyServer{data: data}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+
+		s.mutex.Lock()
+		data, block := s.data, s.block
+		s.mutex.Unlock()
+
+		if block != nil {
+			<-block
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *keyServer) set(data []byte, block chan struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.data, s.block = data, block
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return key
+}
+
+// expire makes the next unknown key ID reload the set.
+func expire(jwks *JWKS) {
+	jwks.mutex.Lock()
+	jwks.lastRefresh = time.Now().Add(-minRefreshInterval)
+	jwks.mutex.Unlock()
+}
+
+func TestJWKSRotation(t *testing.T) {
+	oldKey, newKey := generateRSAKey(t), generateRSAKey(t)
+	badKey := rsaJWK("bad", oldKey)
+	badKey.E = "AQ"
+
+	tests := []struct {
+		name     string
+		initial  []jsonWebKey
+		rotated  []jsonWebKey
+		expire   bool
+		wantKeys map[string]bool
+	}{
+		{
+			name:     "new key is fetched on first use",
+			initial:  []jsonWebKey{rsaJWK("old", oldKey)},
+			rotated:  []jsonWebKey{rsaJWK("old", oldKey), rsaJWK("new", newKey)},
+			expire:   true,
+			wantKeys: map[string]bool{"old": true, "new": true},
+		},
+		{
+			name:     "unknown key does not refetch within the minimum interval",
+			initial:  []jsonWebKey{rsaJWK("old", oldKey)},
+			rotated:  []jsonWebKey{rsaJWK("old", oldKey), rsaJWK("new", newKey)},
+			wantKeys: map[string]bool{"old": true, "new": false},
+		},
+		{
+			name:     "invalid key is skipped",
+			initial:  []jsonWebKey{badKey, rsaJWK("old", oldKey)},
+			rotated:  []jsonWebKey{badKey, rsaJWK("old", oldKey), rsaJWK("new", newKey)},
+			expire:   true,
+			wantKeys: map[string]bool{"bad": false, "old": true, "new": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newKeyServer(t, marshalJWKS(t, tt.initial...))
+			jwks, err := NewJWKSFromURL(server.URL)
+			if err != nil {
+				t.Fatalf("NewJWKSFromURL: %v", err)
+			}
+
+			server.set(marshalJWKS(t, tt.rotated...), nil)
+			if tt.expire {
+				expire(jwks)
+			}
+
+			for kid, want := range tt.wantKeys {
+				_, err := jwks.Key(kid)
+				if got := err == nil; got != want {
+					t.Errorf("Key(%q) = %v, want found = %v", kid, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJWKSRemovedKeyIsDropped(t *testing.T) {
+	oldKey, newKey := generateRSAKey(t), generateRSAKey(t)
+
+	server := newKeyServer(t, marshalJWKS(t, rsaJWK("old", oldKey), rsaJWK("new", newKey)))
+	jwks, err := NewJWKSFromURL(server.URL)
+	if err != nil {
+		t.Fatalf("NewJWKSFromURL: %v", err)
+	}
+
+	server.set(marshalJWKS(t, rsaJWK("new", newKey)), nil)
+	if err := jwks.refresh(context.Background(), 0); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	if _, err := jwks.Key("old"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Key(old) = %v, want %v", err, ErrUnknownKey)
+	}
+	if _, err := jwks.Key("new"); err != nil {
+		t.Errorf("Key(new): %v", err)
+	}
+}
+
+func TestJWKSFailedRefreshKeepsKeys(t *testing.T) {
+	key := generateRSAKey(t)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "malformed document", data: []byte("not json")},
+		{name: "no usable keys", data: []byte(`{"keys":[{"kty":"RSA","kid":"bad","n":"","e":"AQAB"}]}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newKeyServer(t, marshalJWKS(t, rsaJWK("rsa-1", key)))
+			jwks, err := NewJWKSFromURL(server.URL)
+			if err != nil {
+				t.Fatalf("NewJWKSFromURL: %v", err)
+			}
+
+			server.set(tt.data, nil)
+			if err := jwks.refresh(context.Background(), 0); err == nil {
+				t.Fatal("refresh succeeded, want an error")
+			}
+			if _, err := jwks.Key("rsa-1"); err != nil {
+				t.Errorf("Key(rsa-1) after failed refresh: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWKSRefreshDoesNotBlockLookups(t *testing.T) {
+	key := generateRSAKey(t)
+
+	server := newKeyServer(t, marshalJWKS(t, rsaJWK("rsa-1", key)))
+	jwks, err := NewJWKSFromURL(server.URL)
+	if err != nil {
+		t.Fatalf("NewJWKSFromURL: %v", err)
+	}
+
+	block := make(chan struct{})
+	server.set(marshalJWKS(t, rsaJWK("rsa-1", key)), block)
+	expire(jwks)
+
+	// Concurrent lookups of unknown keys share one fetch
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jwks.Key("unknown")
+		}()
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for server.fetches.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := jwks.Key("rsa-1")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Key(rsa-1) during refresh: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Key(rsa-1) blocked on the refresh")
+	}
+
+	close(block)
+	wg.Wait()
+
+	if got := server.fetches.Load(); got != 2 {
+		t.Errorf("fetches = %d, want 2", got)
+	}
+}
+
+func TestJWKSRun(t *testing.T) {
+	oldKey, newKey := generateRSAKey(t), generateRSAKey(t)
+
+	server := newKeyServer(t, marshalJWKS(t, rsaJWK("old", oldKey)))
+	jwks, err := NewJWKSFromURL(server.URL)
+	if err != nil {
+		t.Fatalf("NewJWKSFromURL: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		jwks.Run(ctx, 10*time.Millisecond)
+		close(stopped)
+	}()
+
+	server.set(marshalJWKS(t, rsaJWK("new", newKey)), nil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(jwks.Keys()) != 1 || !hasKey(jwks, "new") {
+		if time.Now().After(deadline) {
+			t.Fatal("Run did not pick up the rotated key set")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("Run did not stop when its context was cancelled")
+	}
+}
+
+func hasKey(jwks *JWKS, kid string) bool {
+	jwks.mutex.RLock()
+	defer jwks.mutex.RUnlock()
+	_, ok := jwks.keys[kid]
+	return ok
+}
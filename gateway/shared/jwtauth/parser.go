@@ -0,0 +1,147 @@
+// Package jwtauth verifies the JWTs issued by the auth service and the API
+// gateway. The gateway and the notification service share it so both accept
+// exactly the same tokens.
+package jwtauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoKeyConfigured = errors.New("no JWT verification key configured")
+	ErrMissingSubject  = errors.New("token has no subject")
+)
+
+// Claims are the JWT claims issued by the auth service and the gateway.
+type Claims struct {
+	UserID      string   `json:"sub"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+
+	// TokenUse is empty for access tokens and "refresh" for refresh tokens.
+	TokenUse string `json:"token_use,omitempty"`
+
+	// AuthTime is when the user logged in. Refreshed tokens keep it, so it
+	// bounds the lifetime of the session.
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type Config struct {
+	// JWKS holds the public keys of RS256 and ES256 tokens.
+	JWKS *JWKS
+
+	// HMACSecret verifies HS256 tokens, as issued by the auth service.
+	HMACSecret string
+
+	// Issuer and Audience, if set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+}
+
+// Parser checks the signature and registered claims of tokens.
+type Parser struct {
+	jwks       *JWKS
+	hmacSecret []byte
+	parser     *jwt.Parser
+}
+
+func NewParser(config Config) (*Parser, error) {
+	if config.JWKS == nil && config.HMACSecret == "" {
+		return nil, ErrNoKeyConfigured
+	}
+
+	var methods []string
+	if config.JWKS != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	if config.HMACSecret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	p := &Parser{
+		jwks:   config.JWKS,
+		parser: jwt.NewParser(options...),
+	}
+	if config.HMACSecret != "" {
+		p.hmacSecret = []byte(config.HMACSecret)
+	}
+	return p, nil
+}
+
+// Parse returns the claims of a token with a valid signature, unexpired
+// registered claims and a subject. It accepts access and refresh tokens
+// alike; callers check TokenUse.
+func (p *Parser) Parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := p.parser.ParseWithClaims(tokenString, claims, p.key); err != nil {
+		return nil, err
+	}
+	if claims.UserID == "" {
+		return nil, ErrMissingSubject
+	}
+	return claims, nil
+}
+
+// key selects the verification key for a token: the HMAC secret for HS256,
+// otherwise the JWKS key named by the kid header, or every key of the right
+// type when the token names none.
+func (p *Parser) key(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return p.hmacSecret, nil
+
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+			key, err := p.jwks.Key(kid)
+			if err != nil {
+				return nil, err
+			}
+			if !keyMatches(token.Method, key) {
+				return nil, jwt.ErrTokenSignatureInvalid
+			}
+			return key, nil
+		}
+
+		var keys jwt.VerificationKeySet
+		for _, key := range p.jwks.Keys() {
+			if keyMatches(token.Method, key) {
+				keys.Keys = append(keys.Keys, key)
+			}
+		}
+		if len(keys.Keys) == 0 {
+			return nil, ErrUnknownKey
+		}
+		return keys, nil
+	}
+
+	return nil, jwt.ErrTokenSignatureInvalid
+}
+
+func keyMatches(method jwt.SigningMethod, key any) bool {
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case *jwt.SigningMethodECDSA:
+		_, ok := key.(*ecdsa.PublicKey)
+		return ok
+	}
+	return false
+}
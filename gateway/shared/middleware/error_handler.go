@@ -21,7 +21,7 @@ func ErrorHandler() gin.HandlerFunc {
 			err := c.Errors.Last()
 
 			// Log the error
-			zap.L().Error("Request error",
+			RequestLogger(c).Error("Request error",
 				zap.String("method", c.Request.Method),
 				zap.String("path", c.Request.URL.Path),
 				zap.String("error", err.Error()),
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// RequestIDHeader carries the request ID in requests and responses.
+	RequestIDHeader = "X-Request-ID"
+
+	// RequestIDKey is the gin context key of the request ID.
+	RequestIDKey = "requestID"
+
+	// Longest request ID accepted from a client.
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+type loggerKey struct{}
+
+// RequestID takes the request ID from the X-Request-ID header, or generates
+// one, and echoes it in the response. It is stored under RequestIDKey and in
+// the request context together with a logger that adds it to every line, see
+// RequestLogger. It must run before Logger.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = rand.Text()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := context.WithValue(c.Request.Context(), requestIDKey{}, requestID)
+		ctx = context.WithValue(ctx, loggerKey{}, zap.L().With(zap.String("request_id", requestID)))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// RequestIDFromContext returns the request ID stored by RequestID, or an
+// empty string. ctx may be a *gin.Context or a context derived from the
+// request context.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := requestContext(ctx).Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// RequestLogger returns the logger of the request, which adds the request ID
+// to every line, or the global logger outside of a request.
+func RequestLogger(ctx context.Context) *zap.Logger {
+	if logger, ok := requestContext(ctx).Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
+
+// requestContext unwraps a *gin.Context, whose Value does not look into the
+// request context.
+func requestContext(ctx context.Context) context.Context {
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil {
+		return c.Request.Context()
+	}
+	return ctx
+}
+
+// validRequestID accepts IDs of printable ASCII characters without spaces,
+// so a client cannot inject anything into logs or headers.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -42,6 +42,25 @@ protoc --go_out=gateway/internal/grpc/audit --go_opt=paths=source_relative \
        --go-grpc_out=gateway/internal/grpc/audit --go-grpc_opt=paths=source_relative \
        proto/audit.proto
 
+# Generate Go server code for the Go services
+mkdir -p services/notification/internal/grpc services/audit/internal/grpc
+
+protoc --go_out=services/notification/internal/grpc --go_opt=paths=source_relative \
+       --go-grpc_out=services/notification/internal/grpc --go-grpc_opt=paths=source_relative \
+       proto/notification.proto
+
+# Clients used by the notification service to check topic membership
+for service in contract dispute; do
+    mkdir -p services/notification/internal/grpc/$service
+    protoc --go_out=services/notification/internal/grpc/$service --go_opt=paths=source_relative \
+           --go-grpc_out=services/notification/internal/grpc/$service --go-grpc_opt=paths=source_relative \
+           proto/$service.proto
+done
+
+protoc --go_out=services/audit/internal/grpc --go_opt=paths=source_relative \
+       --go-grpc_out=services/audit/internal/grpc --go-grpc_opt=paths=source_relative \
+       proto/audit.proto
+
 echo "✅ gRPC Go code generated successfully!"
 
 # Create output directories for NestJS services
@@ -34,3 +34,22 @@ db.logs.createIndex({
 });
 
 print('MongoDB audit database initialized successfully!');
+
+// Notification database used by the notification service inbox
+db = db.getSiblingDB('notification_db');
+
+db.createUser({
+  user: 'notification_user',
+  pwd: 'notification_password',
+  roles: [
+    {
+      role: 'readWrite',
+      db: 'notification_db'
+    }
+  ]
+});
+
+db.notifications.createIndex({ "userId": 1, "timestamp": -1 });
+db.notifications.createIndex({ "userId": 1, "read": 1, "timestamp": -1 });
+
+print('MongoDB notification database initialized successfully!');
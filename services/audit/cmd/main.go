@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,9 +13,14 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"audit-service/internal/database"
+	auditGrpc "audit-service/internal/grpc"
+	pb "audit-service/internal/grpc/proto"
 	"audit-service/internal/handlers"
+	"audit-service/shared/grpchealth"
+	"audit-service/shared/grpcmeta"
 	"audit-service/shared/logger"
 	"audit-service/shared/middleware"
 )
@@ -42,6 +48,7 @@ func main() {
 	router := gin.New()
 
 	// Middleware
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger())
 	router.Use(middleware.ErrorHandler())
 	router.Use(cors.New(cors.Config{
@@ -71,10 +78,18 @@ func main() {
 		port = "8082"
 	}
 
-	// Validate port
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50056"
+	}
+
+	// Validate ports
 	if _, err := strconv.Atoi(port); err != nil {
 		zap.L().Fatal("Invalid port number", zap.String("port", port))
 	}
+	if _, err := strconv.Atoi(grpcPort); err != nil {
+		zap.L().Fatal("Invalid gRPC port number", zap.String("port", grpcPort))
+	}
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -97,12 +112,44 @@ func main() {
 		}
 	}()
 
+	// Create gRPC server
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcmeta.UnaryServerInterceptor()),
+	)
+	pb.RegisterAuditServiceServer(grpcServer, auditGrpc.NewAuditServer())
+
+	// Report NOT_SERVING while the database is down so the gateway stops
+	// routing to this instance
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	defer stopHealth()
+	healthServer := grpchealth.Register(healthCtx, grpcServer, func(ctx context.Context) error {
+		return database.Client.Ping(ctx, nil)
+	})
+
+	// Start gRPC server in a goroutine
+	go func() {
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			zap.L().Fatal("Failed to listen for gRPC", zap.String("port", grpcPort), zap.Error(err))
+		}
+
+		zap.L().Info("Starting Audit gRPC Service", zap.String("port", grpcPort))
+
+		if err := grpcServer.Serve(listener); err != nil {
+			zap.L().Fatal("Failed to start gRPC server", zap.Error(err))
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	zap.L().Info("Shutting down server...")
 
+	// Stop accepting gRPC calls and let in-flight ones finish
+	healthServer.Shutdown()
+	grpcServer.GracefulStop()
+
 	// Give outstanding requests a deadline for completion
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -79,6 +79,11 @@ func createIndexes() error {
 				"action": 1,
 			},
 		},
+		{
+			Keys: map[string]interface{}{
+				"resource": 1,
+			},
+		},
 		{
 			Keys: map[string]interface{}{
 				"timestamp": -1,
@@ -0,0 +1,256 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"audit-service/internal/database"
+	pb "audit-service/internal/grpc/proto"
+	"audit-service/internal/models"
+	"audit-service/shared/grpcmeta"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 100
+)
+
+type AuditServer struct {
+	pb.UnimplementedAuditServiceServer
+}
+
+func NewAuditServer() *AuditServer {
+	return &AuditServer{}
+}
+
+func (s *AuditServer) CreateLog(ctx context.Context, req *pb.CreateLogRequest) (*pb.CreateLogResponse, error) {
+	if req.Action == "" {
+		return &pb.CreateLogResponse{
+			Success: false,
+			Error:   "INVALID_REQUEST",
+			Message: "Action is required",
+		}, nil
+	}
+
+	log := &models.Log{
+		ID:        primitive.NewObjectID(),
+		Action:    req.Action,
+		Resource:  req.Resource,
+		Metadata:  metadataToMap(req.Metadata),
+		IPAddress: req.IpAddress,
+		UserAgent: req.UserAgent,
+	}
+	userID := req.UserId
+	if userID == "" {
+		// Events logged by the gateway for the authenticated caller
+		if caller, ok := grpcmeta.FromContext(ctx); ok {
+			userID = caller.UserID
+		}
+	}
+	if userID != "" {
+		log.UserID = &userID
+	}
+	log.SetDefaults()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := database.LogsCollection.InsertOne(ctx, log)
+	if err != nil {
+		grpcmeta.Logger(ctx).Error("Failed to create log", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create audit log")
+	}
+
+	// Update the ID from the insert result
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		log.ID = oid
+	}
+
+	grpcmeta.Logger(ctx).Info("Audit log created via gRPC",
+		zap.String("id", log.ID.Hex()),
+		zap.String("action", log.Action),
+		zap.String("resource", log.Resource),
+		zap.Stringp("userID", log.UserID),
+	)
+
+	return &pb.CreateLogResponse{
+		Success: true,
+		Message: "Audit log created",
+		Data:    toLogData(log),
+	}, nil
+}
+
+func (s *AuditServer) GetLogs(ctx context.Context, req *pb.GetLogsRequest) (*pb.GetLogsResponse, error) {
+	filter := bson.M{}
+	if req.Action != "" {
+		filter["action"] = req.Action
+	}
+	if req.Resource != "" {
+		filter["resource"] = req.Resource
+	}
+	if err := applyDateRange(filter, req.StartDate, req.EndDate); err != nil {
+		return &pb.GetLogsResponse{
+			Success: false,
+			Error:   "INVALID_REQUEST",
+			Message: err.Error(),
+		}, nil
+	}
+
+	logs, total, err := findLogs(ctx, filter, req.Page, req.Limit)
+	if err != nil {
+		grpcmeta.Logger(ctx).Error("Failed to get logs", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to retrieve logs")
+	}
+
+	return &pb.GetLogsResponse{
+		Success: true,
+		Data:    logs,
+		Total:   int32(total),
+	}, nil
+}
+
+func (s *AuditServer) GetLogsByUser(ctx context.Context, req *pb.GetLogsByUserRequest) (*pb.GetLogsByUserResponse, error) {
+	if req.UserId == "" {
+		return &pb.GetLogsByUserResponse{
+			Success: false,
+			Error:   "INVALID_REQUEST",
+			Message: "User ID is required",
+		}, nil
+	}
+
+	filter := bson.M{"userId": req.UserId}
+	if err := applyDateRange(filter, req.StartDate, req.EndDate); err != nil {
+		return &pb.GetLogsByUserResponse{
+			Success: false,
+			Error:   "INVALID_REQUEST",
+			Message: err.Error(),
+		}, nil
+	}
+
+	logs, total, err := findLogs(ctx, filter, req.Page, req.Limit)
+	if err != nil {
+		grpcmeta.Logger(ctx).Error("Failed to get user logs", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to retrieve logs")
+	}
+
+	return &pb.GetLogsByUserResponse{
+		Success: true,
+		Data:    logs,
+		Total:   int32(total),
+	}, nil
+}
+
+// findLogs runs a paginated query sorted by newest first and returns the
+// matching page together with the total number of matching documents.
+func findLogs(ctx context.Context, filter bson.M, page, limit int32) ([]*pb.LogData, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	skip := int64(page-1) * int64(limit)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	total, err := database.LogsCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(int64(limit))
+
+	cursor, err := database.LogsCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []models.Log
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, 0, err
+	}
+
+	data := make([]*pb.LogData, len(logs))
+	for i := range logs {
+		data[i] = toLogData(&logs[i])
+	}
+
+	return data, total, nil
+}
+
+// applyDateRange adds a timestamp range to filter from RFC3339 bounds.
+// Empty bounds are ignored.
+func applyDateRange(filter bson.M, startDate, endDate string) error {
+	timestamp := bson.M{}
+
+	if startDate != "" {
+		start, err := time.Parse(time.RFC3339, startDate)
+		if err != nil {
+			return errors.New("invalid startDate: expected RFC3339")
+		}
+		timestamp["$gte"] = start
+	}
+
+	if endDate != "" {
+		end, err := time.Parse(time.RFC3339, endDate)
+		if err != nil {
+			return errors.New("invalid endDate: expected RFC3339")
+		}
+		timestamp["$lte"] = end
+	}
+
+	if len(timestamp) > 0 {
+		filter["timestamp"] = timestamp
+	}
+	return nil
+}
+
+func metadataToMap(metadata map[string]string) map[string]interface{} {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		result[key] = value
+	}
+	return result
+}
+
+func toLogData(l *models.Log) *pb.LogData {
+	data := &pb.LogData{
+		Id:        l.ID.Hex(),
+		Action:    l.Action,
+		Resource:  l.Resource,
+		IpAddress: l.IPAddress,
+		UserAgent: l.UserAgent,
+		CreatedAt: l.CreatedAt.Format(time.RFC3339),
+	}
+	if l.UserID != nil {
+		data.UserId = *l.UserID
+	}
+	if len(l.Metadata) > 0 {
+		data.Metadata = make(map[string]string, len(l.Metadata))
+		for key, value := range l.Metadata {
+			data.Metadata[key] = fmt.Sprint(value)
+		}
+	}
+	return data
+}
@@ -13,6 +13,7 @@ import (
 
 	"audit-service/internal/database"
 	"audit-service/internal/models"
+	"audit-service/shared/middleware"
 	"audit-service/shared/response"
 )
 
@@ -25,16 +26,19 @@ func NewAuditHandler() *AuditHandler {
 func (h *AuditHandler) CreateLog(c *gin.Context) {
 	var req models.CreateLogRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		zap.L().Error("Invalid log request", zap.Error(err))
+		middleware.RequestLogger(c).Error("Invalid log request", zap.Error(err))
 		response.BadRequest(c, "Invalid request payload")
 		return
 	}
 
 	log := &models.Log{
-		ID:       primitive.NewObjectID(),
-		UserID:   req.UserID,
-		Action:   req.Action,
-		Metadata: req.Metadata,
+		ID:        primitive.NewObjectID(),
+		UserID:    req.UserID,
+		Action:    req.Action,
+		Resource:  req.Resource,
+		Metadata:  req.Metadata,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
 	}
 	log.SetDefaults()
 
@@ -43,7 +47,7 @@ func (h *AuditHandler) CreateLog(c *gin.Context) {
 
 	result, err := database.LogsCollection.InsertOne(ctx, log)
 	if err != nil {
-		zap.L().Error("Failed to create log", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to create log", zap.Error(err))
 		response.InternalError(c, "Failed to create audit log")
 		return
 	}
@@ -53,7 +57,7 @@ func (h *AuditHandler) CreateLog(c *gin.Context) {
 		log.ID = oid
 	}
 
-	zap.L().Info("Audit log created",
+	middleware.RequestLogger(c).Info("Audit log created",
 		zap.String("id", log.ID.Hex()),
 		zap.String("action", log.Action),
 		zap.Stringp("userID", log.UserID),
@@ -96,20 +100,20 @@ func (h *AuditHandler) GetUserLogs(c *gin.Context) {
 	// Get total count
 	total, err := database.LogsCollection.CountDocuments(ctx, filter)
 	if err != nil {
-		zap.L().Error("Failed to count user logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to count user logs", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve logs")
 		return
 	}
 
 	// Find options with pagination and sorting
 	findOptions := options.Find().
-		SetSort(bson.D{{"timestamp", -1}}).
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
 		SetSkip(int64(skip)).
 		SetLimit(int64(limit))
 
 	cursor, err := database.LogsCollection.Find(ctx, filter, findOptions)
 	if err != nil {
-		zap.L().Error("Failed to get user logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to get user logs", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve logs")
 		return
 	}
@@ -117,7 +121,7 @@ func (h *AuditHandler) GetUserLogs(c *gin.Context) {
 
 	var logs []models.Log
 	if err := cursor.All(ctx, &logs); err != nil {
-		zap.L().Error("Failed to decode logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to decode logs", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve logs")
 		return
 	}
@@ -195,20 +199,20 @@ func (h *AuditHandler) GetAllLogs(c *gin.Context) {
 	// Get total count
 	total, err := database.LogsCollection.CountDocuments(ctx, filter)
 	if err != nil {
-		zap.L().Error("Failed to count logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to count logs", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve logs")
 		return
 	}
 
 	// Find options with pagination and sorting
 	findOptions := options.Find().
-		SetSort(bson.D{{"timestamp", -1}}).
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
 		SetSkip(int64(skip)).
 		SetLimit(int64(limit))
 
 	cursor, err := database.LogsCollection.Find(ctx, filter, findOptions)
 	if err != nil {
-		zap.L().Error("Failed to get logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to get logs", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve logs")
 		return
 	}
@@ -216,7 +220,7 @@ func (h *AuditHandler) GetAllLogs(c *gin.Context) {
 
 	var logs []models.Log
 	if err := cursor.All(ctx, &logs); err != nil {
-		zap.L().Error("Failed to decode logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to decode logs", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve logs")
 		return
 	}
@@ -264,7 +268,7 @@ func (h *AuditHandler) GetLogAnalytics(c *gin.Context) {
 
 	cursor, err := database.LogsCollection.Aggregate(ctx, pipeline)
 	if err != nil {
-		zap.L().Error("Failed to get analytics", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to get analytics", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve analytics")
 		return
 	}
@@ -272,7 +276,7 @@ func (h *AuditHandler) GetLogAnalytics(c *gin.Context) {
 
 	var analytics []models.LogAggregationResult
 	if err := cursor.All(ctx, &analytics); err != nil {
-		zap.L().Error("Failed to decode analytics", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to decode analytics", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve analytics")
 		return
 	}
@@ -280,7 +284,7 @@ func (h *AuditHandler) GetLogAnalytics(c *gin.Context) {
 	// Get total logs count
 	total, err := database.LogsCollection.CountDocuments(ctx, bson.M{})
 	if err != nil {
-		zap.L().Error("Failed to count total logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to count total logs", zap.Error(err))
 		response.InternalError(c, "Failed to retrieve analytics")
 		return
 	}
@@ -333,20 +337,20 @@ func (h *AuditHandler) SearchLogs(c *gin.Context) {
 	// Get total count
 	total, err := database.LogsCollection.CountDocuments(ctx, filter)
 	if err != nil {
-		zap.L().Error("Failed to count search results", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to count search results", zap.Error(err))
 		response.InternalError(c, "Failed to search logs")
 		return
 	}
 
 	// Find options with pagination and sorting
 	findOptions := options.Find().
-		SetSort(bson.D{{"timestamp", -1}}).
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
 		SetSkip(int64(skip)).
 		SetLimit(int64(limit))
 
 	cursor, err := database.LogsCollection.Find(ctx, filter, findOptions)
 	if err != nil {
-		zap.L().Error("Failed to search logs", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to search logs", zap.Error(err))
 		response.InternalError(c, "Failed to search logs")
 		return
 	}
@@ -354,7 +358,7 @@ func (h *AuditHandler) SearchLogs(c *gin.Context) {
 
 	var logs []models.Log
 	if err := cursor.All(ctx, &logs); err != nil {
-		zap.L().Error("Failed to decode search results", zap.Error(err))
+		middleware.RequestLogger(c).Error("Failed to decode search results", zap.Error(err))
 		response.InternalError(c, "Failed to search logs")
 		return
 	}
@@ -389,7 +393,7 @@ func (h *AuditHandler) HealthCheck(c *gin.Context) {
 	defer cancel()
 
 	if err := database.Client.Ping(ctx, nil); err != nil {
-		zap.L().Error("MongoDB health check failed", zap.Error(err))
+		middleware.RequestLogger(c).Error("MongoDB health check failed", zap.Error(err))
 		response.InternalError(c, "Database connection failed")
 		return
 	}
@@ -10,23 +10,32 @@ type Log struct {
 	ID        primitive.ObjectID     `json:"id" bson:"_id,omitempty"`
 	UserID    *string                `json:"userId,omitempty" bson:"userId,omitempty"`
 	Action    string                 `json:"action" bson:"action"`
+	Resource  string                 `json:"resource,omitempty" bson:"resource,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	IPAddress string                 `json:"ipAddress,omitempty" bson:"ipAddress,omitempty"`
+	UserAgent string                 `json:"userAgent,omitempty" bson:"userAgent,omitempty"`
 	Timestamp time.Time              `json:"timestamp" bson:"timestamp"`
 	CreatedAt time.Time              `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time              `json:"updatedAt" bson:"updatedAt"`
 }
 
 type CreateLogRequest struct {
-	UserID   *string                `json:"userId,omitempty" binding:"omitempty"`
-	Action   string                 `json:"action" binding:"required"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	UserID    *string                `json:"userId,omitempty" binding:"omitempty"`
+	Action    string                 `json:"action" binding:"required"`
+	Resource  string                 `json:"resource,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	IPAddress string                 `json:"ipAddress,omitempty"`
+	UserAgent string                 `json:"userAgent,omitempty"`
 }
 
 type LogResponse struct {
 	ID        string                 `json:"id"`
 	UserID    *string                `json:"userId,omitempty"`
 	Action    string                 `json:"action"`
+	Resource  string                 `json:"resource,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	IPAddress string                 `json:"ipAddress,omitempty"`
+	UserAgent string                 `json:"userAgent,omitempty"`
 	Timestamp time.Time              `json:"timestamp"`
 	CreatedAt time.Time              `json:"createdAt"`
 }
@@ -53,7 +62,10 @@ func (l *Log) ToResponse() LogResponse {
 		ID:        l.ID.Hex(),
 		UserID:    l.UserID,
 		Action:    l.Action,
+		Resource:  l.Resource,
 		Metadata:  l.Metadata,
+		IPAddress: l.IPAddress,
+		UserAgent: l.UserAgent,
 		Timestamp: l.Timestamp,
 		CreatedAt: l.CreatedAt,
 	}
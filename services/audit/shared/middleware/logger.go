@@ -27,7 +27,7 @@ func Logger() gin.HandlerFunc {
 			path = path + "?" + raw
 		}
 
-		zap.L().Info("HTTP Request",
+		RequestLogger(c).Info("HTTP Request",
 			zap.String("method", method),
 			zap.String("path", path),
 			zap.Int("status", statusCode),
@@ -52,6 +52,14 @@ func Unauthorized(c *gin.Context, message string) {
 	})
 }
 
+func Forbidden(c *gin.Context, message string) {
+	c.JSON(http.StatusForbidden, APIResponse{
+		Success: false,
+		Error:   "FORBIDDEN",
+		Message: message,
+	})
+}
+
 func NotFound(c *gin.Context, message string) {
 	c.JSON(http.StatusNotFound, APIResponse{
 		Success: false,
@@ -8,6 +8,7 @@ import { InjectRepository } from '@nestjs/typeorm';
 import { JwtService } from '@nestjs/jwt';
 import { Repository } from 'typeorm';
 import * as bcrypt from 'bcryptjs';
+import { randomUUID } from 'crypto';
 import { User } from '../entities/user.entity';
 import { RegisterDto, LoginDto, AuthResponseDto } from '../dto/auth.dto';
 
@@ -40,13 +41,18 @@ export class AuthService {
       password: hashedPassword,
       firstName,
       lastName,
+      roles: ['user'],
     });
 
     const savedUser = await this.userRepository.save(user);
 
     // Generate JWT token
-    const payload = { sub: savedUser.id, email: savedUser.email };
-    const access_token = this.jwtService.sign(payload);
+    const payload = {
+      sub: savedUser.id,
+      email: savedUser.email,
+      roles: savedUser.roles,
+    };
+    const access_token = this.jwtService.sign(payload, { jwtid: randomUUID() });
 
     return {
       access_token,
@@ -77,8 +83,8 @@ export class AuthService {
     }
 
     // Generate JWT token
-    const payload = { sub: user.id, email: user.email };
-    const access_token = this.jwtService.sign(payload);
+    const payload = { sub: user.id, email: user.email, roles: user.roles };
+    const access_token = this.jwtService.sign(payload, { jwtid: randomUUID() });
 
     return {
       access_token,
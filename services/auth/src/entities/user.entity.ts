@@ -25,6 +25,10 @@ export class User {
   @Column()
   lastName: string;
 
+  // Comma-separated in the database, e.g. "user,mediator"
+  @Column('simple-array', { default: 'user' })
+  roles: string[];
+
   @CreateDateColumn()
   createdAt: Date;
 
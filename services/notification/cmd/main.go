@@ -2,19 +2,34 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
+	"notification-service/internal/auth"
+	"notification-service/internal/backplane"
+	notificationGrpc "notification-service/internal/grpc"
+	contractpb "notification-service/internal/grpc/contract/proto"
+	disputepb "notification-service/internal/grpc/dispute/proto"
+	pb "notification-service/internal/grpc/proto"
 	"notification-service/internal/handlers"
+	"notification-service/internal/store"
+	"notification-service/internal/topics"
 	"notification-service/internal/websocket"
+	"notification-service/shared/grpchealth"
+	"notification-service/shared/grpcmeta"
+	"notification-service/shared/jwtauth"
 	"notification-service/shared/logger"
 	"notification-service/shared/middleware"
 )
@@ -24,22 +39,107 @@ func main() {
 	logger.Init("notification-service")
 	defer logger.Sync()
 
+	// Initialize notification store
+	var notificationStore store.NotificationStore
+	if mongoURI := os.Getenv("MONGODB_URI"); mongoURI != "" {
+		mongoStore, err := store.NewMongoStore(mongoURI)
+		if err != nil {
+			zap.L().Fatal("Failed to connect to MongoDB", zap.Error(err))
+		}
+		notificationStore = mongoStore
+	} else {
+		zap.L().Warn("MONGODB_URI not set, notifications will only be kept in memory")
+		notificationStore = store.NewMemoryStore()
+	}
+
 	// Set Gin mode
 	if os.Getenv("GIN_MODE") == "" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Initialize notification backplane
+	var notificationBackplane backplane.Backplane
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		redisBackplane, err := backplane.NewRedisBackplane(redisURL)
+		if err != nil {
+			zap.L().Fatal("Failed to connect to Redis", zap.Error(err))
+		}
+		notificationBackplane = redisBackplane
+	} else {
+		zap.L().Warn("REDIS_URL not set, notifications will only reach clients of this instance")
+		notificationBackplane = backplane.NewInProcessBackplane()
+	}
+	defer notificationBackplane.Close()
+
+	// Connect to the services that own topic membership
+	contractConn, err := grpc.NewClient(getEnv("CONTRACT_GRPC_ADDR", "localhost:50052"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create contract service client", zap.Error(err))
+	}
+	defer contractConn.Close()
+
+	disputeConn, err := grpc.NewClient(getEnv("DISPUTE_GRPC_ADDR", "localhost:50054"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		zap.L().Fatal("Failed to create dispute service client", zap.Error(err))
+	}
+	defer disputeConn.Close()
+
+	// Token validation accepts the same tokens as the API gateway
+	validatorConfig, err := newValidatorConfig()
+	if err != nil {
+		zap.L().Fatal("Failed to configure token verification", zap.Error(err))
+	}
+	// Tokens revoked through the gateway are rejected too when both share
+	// Redis
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		revocations, err := jwtauth.NewRedisRevocationList(redisURL, 0)
+		if err != nil {
+			zap.L().Fatal("Failed to configure token revocation", zap.Error(err))
+		}
+		defer revocations.Close()
+		validatorConfig.Revocations = revocations
+	} else {
+		zap.L().Warn("REDIS_URL not set, tokens revoked through the gateway are accepted until they expire")
+	}
+	if validatorConfig.JWKS != nil {
+		// Reload the key set in the background so rotated keys are picked up
+		jwksCtx, stopJWKS := context.WithCancel(context.Background())
+		defer stopJWKS()
+		go validatorConfig.JWKS.Run(jwksCtx, getEnvDuration("JWT_JWKS_REFRESH_INTERVAL", jwtauth.DefaultRefreshInterval))
+	}
+	validator, err := auth.NewTokenValidator(validatorConfig)
+	if err != nil {
+		zap.L().Fatal("Failed to configure token verification", zap.Error(err))
+	}
+
 	// Create WebSocket hub
-	hub := websocket.NewHub()
+	hub, err := websocket.NewHub(websocket.HubConfig{
+		Validator:      validator,
+		AllowedOrigins: getEnvList("WS_ALLOWED_ORIGINS"),
+		Backplane:      notificationBackplane,
+		Store:          notificationStore,
+		Topics: topics.NewMembershipAuthorizer(
+			contractpb.NewContractServiceClient(contractConn),
+			disputepb.NewDisputeServiceClient(disputeConn),
+		),
+	})
+	if err != nil {
+		zap.L().Fatal("Failed to subscribe to notification backplane", zap.Error(err))
+	}
 	go hub.Run()
 
 	// Initialize handlers
-	notificationHandler := handlers.NewNotificationHandler(hub)
+	notificationHandler := handlers.NewNotificationHandler(hub, notificationStore)
 
 	// Setup router
 	router := gin.New()
 
 	// Middleware
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger())
 	router.Use(middleware.ErrorHandler())
 	router.Use(cors.New(cors.Config{
@@ -60,6 +160,9 @@ func main() {
 	// WebSocket endpoint
 	router.GET("/ws", hub.ServeWS)
 
+	// Server-Sent Events fallback for clients that cannot use WebSockets
+	router.GET("/events", hub.ServeSSE)
+
 	// Health check
 	router.GET("/health", notificationHandler.HealthCheck)
 
@@ -69,10 +172,18 @@ func main() {
 		port = "8081"
 	}
 
-	// Validate port
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50055"
+	}
+
+	// Validate ports
 	if _, err := strconv.Atoi(port); err != nil {
 		zap.L().Fatal("Invalid port number", zap.String("port", port))
 	}
+	if _, err := strconv.Atoi(grpcPort); err != nil {
+		zap.L().Fatal("Invalid gRPC port number", zap.String("port", grpcPort))
+	}
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -95,12 +206,42 @@ func main() {
 		}
 	}()
 
+	// Create gRPC server
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcmeta.UnaryServerInterceptor()),
+	)
+	pb.RegisterNotificationServiceServer(grpcServer, notificationGrpc.NewNotificationServer(hub, notificationStore))
+
+	// Report NOT_SERVING while the database is down so the gateway stops
+	// routing to this instance
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	defer stopHealth()
+	healthServer := grpchealth.Register(healthCtx, grpcServer, notificationStore.Ping)
+
+	// Start gRPC server in a goroutine
+	go func() {
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			zap.L().Fatal("Failed to listen for gRPC", zap.String("port", grpcPort), zap.Error(err))
+		}
+
+		zap.L().Info("Starting Notification gRPC Service", zap.String("port", grpcPort))
+
+		if err := grpcServer.Serve(listener); err != nil {
+			zap.L().Fatal("Failed to start gRPC server", zap.Error(err))
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	zap.L().Info("Shutting down server...")
 
+	// Stop accepting gRPC calls and let in-flight ones finish
+	healthServer.Shutdown()
+	grpcServer.GracefulStop()
+
 	// Give outstanding requests a deadline for completion
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -109,6 +250,71 @@ func main() {
 		zap.L().Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	if err := notificationStore.Close(ctx); err != nil {
+		zap.L().Error("Failed to close notification store", zap.Error(err))
+	}
+
 	zap.L().Info("Server exited")
 }
 
+// newValidatorConfig configures JWT verification like the API gateway: from
+// JWT_JWKS_URL or JWT_JWKS_FILE for RS256/ES256 tokens and JWT_SECRET for
+// HS256 tokens. Outside release mode a development secret is used if neither
+// is set.
+func newValidatorConfig() (auth.ValidatorConfig, error) {
+	config := auth.ValidatorConfig{Config: jwtauth.Config{
+		HMACSecret: os.Getenv("JWT_SECRET"),
+		Issuer:     os.Getenv("JWT_ISSUER"),
+		Audience:   os.Getenv("JWT_AUDIENCE"),
+	}}
+
+	var err error
+	if jwksURL := os.Getenv("JWT_JWKS_URL"); jwksURL != "" {
+		config.JWKS, err = jwtauth.NewJWKSFromURL(jwksURL)
+	} else if jwksFile := os.Getenv("JWT_JWKS_FILE"); jwksFile != "" {
+		config.JWKS, err = jwtauth.NewJWKSFromFile(jwksFile)
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if config.JWKS == nil && config.HMACSecret == "" {
+		if gin.Mode() == gin.ReleaseMode {
+			return config, jwtauth.ErrNoKeyConfigured
+		}
+		zap.L().Warn("No JWT key configured, using the development secret")
+		config.HMACSecret = "your-secret-key"
+	}
+
+	return config, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		zap.L().Fatal("Invalid duration", zap.String("key", key), zap.String("value", value))
+	}
+	return duration
+}
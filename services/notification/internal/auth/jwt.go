@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"notification-service/shared/jwtauth"
+)
+
+var ErrWrongTokenUse = errors.New("token cannot be used here")
+
+// Claims matches the claims issued by the auth service and accepted by the
+// API gateway.
+type Claims = jwtauth.Claims
+
+// ValidatorConfig mirrors the API gateway's token verification settings, so
+// every token the gateway accepts is accepted here too.
+type ValidatorConfig struct {
+	jwtauth.Config
+
+	// Revocations, if set, rejects tokens revoked through the gateway, which
+	// shares the list through Redis.
+	Revocations jwtauth.RevocationList
+}
+
+type TokenValidator struct {
+	parser      *jwtauth.Parser
+	revocations jwtauth.RevocationList
+}
+
+func NewTokenValidator(config ValidatorConfig) (*TokenValidator, error) {
+	parser, err := jwtauth.NewParser(config.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenValidator{
+		parser:      parser,
+		revocations: config.Revocations,
+	}, nil
+}
+
+// Validate checks the signature, registered claims and revocation of an
+// access token and returns its claims. Refresh tokens are rejected.
+func (v *TokenValidator) Validate(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := v.parser.Parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenUse != "" {
+		return nil, ErrWrongTokenUse
+	}
+
+	if v.revocations != nil {
+		revoked, err := v.revocations.IsRevoked(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, jwtauth.ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
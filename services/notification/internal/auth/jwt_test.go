@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"notification-service/shared/jwtauth"
+)
+
+const testSecret = "test-secret"
+
+func TestTokenValidator(t *testing.T) {
+	revocations := jwtauth.NewMemoryRevocationList()
+	if err := revocations.RevokeToken(context.Background(), "revoked", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+
+	validator, err := NewTokenValidator(ValidatorConfig{
+		Config:      jwtauth.Config{HMACSecret: testSecret},
+		Revocations: revocations,
+	})
+	if err != nil {
+		t.Fatalf("NewTokenValidator: %v", err)
+	}
+
+	sign := func(claims jwt.MapClaims) string {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+		if err != nil {
+			t.Fatalf("SignedString: %v", err)
+		}
+		return signed
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "access token", token: sign(jwt.MapClaims{"sub": "alice"})},
+		{name: "refresh token", token: sign(jwt.MapClaims{"sub": "alice", "token_use": "refresh"}), wantErr: ErrWrongTokenUse},
+		{name: "no subject", token: sign(jwt.MapClaims{}), wantErr: jwtauth.ErrMissingSubject},
+		{name: "revoked token", token: sign(jwt.MapClaims{"sub": "alice", "jti": "revoked"}), wantErr: jwtauth.ErrTokenRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validator.Validate(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.UserID != "alice" {
+				t.Errorf("claims = %+v", got)
+			}
+		})
+	}
+}
+
+func TestNewTokenValidatorRequiresKey(t *testing.T) {
+	if _, err := NewTokenValidator(ValidatorConfig{}); !errors.Is(err, jwtauth.ErrNoKeyConfigured) {
+		t.Errorf("NewTokenValidator = %v, want %v", err, jwtauth.ErrNoKeyConfigured)
+	}
+}
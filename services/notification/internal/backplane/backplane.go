@@ -0,0 +1,39 @@
+package backplane
+
+import (
+	"context"
+
+	"notification-service/internal/models"
+)
+
+// Message is a notification fanned out to every notification service
+// replica. It targets the subscribers of Topic if set, otherwise the user
+// named by UserID, otherwise all clients.
+type Message struct {
+	Topic        string               `json:"topic,omitempty"`
+	UserID       string               `json:"userId,omitempty"`
+	Notification *models.Notification `json:"notification"`
+
+	// RequestID is the ID of the request that published the message, so
+	// its delivery is logged with it on every replica.
+	RequestID string `json:"requestId,omitempty"`
+}
+
+type Handler func(msg *Message)
+
+// Backplane distributes notifications between replicas so that each one can
+// deliver them to the clients connected to it.
+type Backplane interface {
+	// Publish sends msg to every subscribed replica, including this one.
+	Publish(ctx context.Context, msg *Message) error
+
+	// Subscribe registers the handler called for every published message.
+	Subscribe(handler Handler) error
+
+	// NextSequence returns the next notification sequence number for a
+	// user. Sequence numbers are shared by all replicas so a client can
+	// resume from any of them.
+	NextSequence(ctx context.Context, userID string) (uint64, error)
+
+	Close() error
+}
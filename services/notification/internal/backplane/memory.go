@@ -0,0 +1,50 @@
+package backplane
+
+import (
+	"context"
+	"sync"
+)
+
+// InProcessBackplane delivers messages to handlers in the same process. It
+// is used for single-replica deployments and tests.
+type InProcessBackplane struct {
+	handlers  []Handler
+	sequences map[string]uint64
+	mutex     sync.RWMutex
+}
+
+func NewInProcessBackplane() *InProcessBackplane {
+	return &InProcessBackplane{
+		sequences: make(map[string]uint64),
+	}
+}
+
+func (b *InProcessBackplane) Publish(ctx context.Context, msg *Message) error {
+	b.mutex.RLock()
+	handlers := b.handlers
+	b.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(msg)
+	}
+	return nil
+}
+
+func (b *InProcessBackplane) Subscribe(handler Handler) error {
+	b.mutex.Lock()
+	b.handlers = append(b.handlers, handler)
+	b.mutex.Unlock()
+	return nil
+}
+
+func (b *InProcessBackplane) NextSequence(ctx context.Context, userID string) (uint64, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.sequences[userID]++
+	return b.sequences[userID], nil
+}
+
+func (b *InProcessBackplane) Close() error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package backplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// Pub/sub channel shared by all notification service replicas.
+	notificationsChannel = "notifications"
+
+	// Prefix of the per-user sequence counters.
+	sequenceKeyPrefix = "notifications:seq:"
+)
+
+// RedisBackplane fans notifications out to every replica through Redis
+// pub/sub and keeps per-user sequence numbers in Redis counters.
+type RedisBackplane struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+}
+
+func NewRedisBackplane(redisURL string) (*RedisBackplane, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	zap.L().Info("Connected to Redis backplane", zap.String("channel", notificationsChannel))
+
+	return &RedisBackplane{
+		client: client,
+	}, nil
+}
+
+func (b *RedisBackplane) Publish(ctx context.Context, msg *Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal backplane message: %w", err)
+	}
+
+	if err := b.client.Publish(ctx, notificationsChannel, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish to Redis: %w", err)
+	}
+	return nil
+}
+
+func (b *RedisBackplane) Subscribe(handler Handler) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pubsub := b.client.Subscribe(ctx, notificationsChannel)
+
+	// Wait for the subscription to be confirmed so no message published
+	// after Subscribe returns is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("failed to subscribe to Redis: %w", err)
+	}
+	b.pubsub = pubsub
+
+	go func() {
+		for redisMsg := range pubsub.Channel() {
+			var msg Message
+			if err := json.Unmarshal([]byte(redisMsg.Payload), &msg); err != nil {
+				zap.L().Error("Failed to decode backplane message", zap.Error(err))
+				continue
+			}
+			handler(&msg)
+		}
+	}()
+
+	return nil
+}
+
+func (b *RedisBackplane) NextSequence(ctx context.Context, userID string) (uint64, error) {
+	seq, err := b.client.Incr(ctx, sequenceKeyPrefix+userID).Uint64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment sequence: %w", err)
+	}
+	return seq, nil
+}
+
+func (b *RedisBackplane) Close() error {
+	if b.pubsub != nil {
+		b.pubsub.Close()
+	}
+	return b.client.Close()
+}
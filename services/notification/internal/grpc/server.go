@@ -0,0 +1,204 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "notification-service/internal/grpc/proto"
+	"notification-service/internal/models"
+	"notification-service/internal/store"
+	"notification-service/internal/websocket"
+	"notification-service/shared/grpcmeta"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+)
+
+type NotificationServer struct {
+	pb.UnimplementedNotificationServiceServer
+	hub   *websocket.Hub
+	store store.NotificationStore
+}
+
+func NewNotificationServer(hub *websocket.Hub, notificationStore store.NotificationStore) *NotificationServer {
+	return &NotificationServer{
+		hub:   hub,
+		store: notificationStore,
+	}
+}
+
+func (s *NotificationServer) SendNotification(ctx context.Context, req *pb.SendNotificationRequest) (*pb.SendNotificationResponse, error) {
+	if req.Title == "" || req.Message == "" || req.Type == "" {
+		return &pb.SendNotificationResponse{
+			Success: false,
+			Error:   "INVALID_REQUEST",
+			Message: "Title, message and type are required",
+		}, nil
+	}
+
+	notification := &models.Notification{
+		ID:        uuid.New().String(),
+		UserID:    req.UserId,
+		Type:      req.Type,
+		Title:     req.Title,
+		Message:   req.Message,
+		Data:      metadataToData(req.Metadata),
+		Timestamp: time.Now(),
+	}
+
+	if err := s.store.Save(ctx, notification); err != nil {
+		grpcmeta.Logger(ctx).Error("Failed to store notification", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to store notification")
+	}
+
+	var err error
+	if req.UserId != "" {
+		err = s.hub.BroadcastToUser(ctx, req.UserId, notification)
+	} else {
+		err = s.hub.BroadcastToAll(ctx, notification)
+	}
+	if err != nil {
+		// The notification is stored, so the user still sees it in their inbox
+		grpcmeta.Logger(ctx).Error("Failed to publish notification", zap.Error(err))
+		return &pb.SendNotificationResponse{
+			Success: true,
+			Message: "Notification stored but not delivered in real time",
+			Data:    toNotificationData(notification),
+		}, nil
+	}
+
+	grpcmeta.Logger(ctx).Info("Notification sent via gRPC",
+		zap.String("id", notification.ID),
+		zap.String("type", notification.Type),
+		zap.String("userID", notification.UserID),
+	)
+
+	return &pb.SendNotificationResponse{
+		Success: true,
+		Message: "Notification sent",
+		Data:    toNotificationData(notification),
+	}, nil
+}
+
+func (s *NotificationServer) GetNotifications(ctx context.Context, req *pb.GetNotificationsRequest) (*pb.GetNotificationsResponse, error) {
+	if req.UserId == "" {
+		return &pb.GetNotificationsResponse{
+			Success: false,
+			Error:   "INVALID_REQUEST",
+			Message: "User ID is required",
+		}, nil
+	}
+
+	opts := store.ListOptions{
+		UserID:     req.UserId,
+		Page:       int(req.Page),
+		Limit:      int(req.Limit),
+		UnreadOnly: req.UnreadOnly,
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.Limit < 1 {
+		opts.Limit = defaultLimit
+	}
+	if opts.Limit > maxLimit {
+		opts.Limit = maxLimit
+	}
+
+	notifications, total, err := s.store.List(ctx, opts)
+	if err != nil {
+		grpcmeta.Logger(ctx).Error("Failed to list notifications", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to retrieve notifications")
+	}
+
+	data := make([]*pb.NotificationData, len(notifications))
+	for i, n := range notifications {
+		data[i] = toNotificationData(n)
+	}
+
+	return &pb.GetNotificationsResponse{
+		Success: true,
+		Data:    data,
+		Total:   int32(total),
+	}, nil
+}
+
+func (s *NotificationServer) MarkAsRead(ctx context.Context, req *pb.MarkAsReadRequest) (*pb.MarkAsReadResponse, error) {
+	if req.NotificationId == "" || req.UserId == "" {
+		return &pb.MarkAsReadResponse{
+			Success: false,
+			Error:   "INVALID_REQUEST",
+			Message: "Notification ID and user ID are required",
+		}, nil
+	}
+
+	err := s.store.MarkAsRead(ctx, req.NotificationId, req.UserId)
+	if errors.Is(err, store.ErrNotFound) {
+		return &pb.MarkAsReadResponse{
+			Success: false,
+			Error:   "NOT_FOUND",
+			Message: "Notification not found",
+		}, nil
+	}
+	if err != nil {
+		grpcmeta.Logger(ctx).Error("Failed to mark notification as read", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to mark notification as read")
+	}
+
+	return &pb.MarkAsReadResponse{
+		Success: true,
+		Message: "Notification marked as read",
+	}, nil
+}
+
+func metadataToData(metadata map[string]string) map[string]interface{} {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	data := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		data[key] = value
+	}
+	return data
+}
+
+func dataToMetadata(data map[string]interface{}) map[string]string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(data))
+	for key, value := range data {
+		metadata[key] = fmt.Sprint(value)
+	}
+	return metadata
+}
+
+func toNotificationData(n *models.Notification) *pb.NotificationData {
+	updatedAt := n.Timestamp
+	if n.ReadAt != nil {
+		updatedAt = *n.ReadAt
+	}
+
+	return &pb.NotificationData{
+		Id:        n.ID,
+		UserId:    n.UserID,
+		Title:     n.Title,
+		Message:   n.Message,
+		Type:      n.Type,
+		Read:      n.Read,
+		Metadata:  dataToMetadata(n.Data),
+		CreatedAt: n.Timestamp.Format(time.RFC3339),
+		UpdatedAt: updatedAt.Format(time.RFC3339),
+	}
+}
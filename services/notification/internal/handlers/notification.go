@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -8,31 +9,47 @@ import (
 	"go.uber.org/zap"
 
 	"notification-service/internal/models"
+	"notification-service/internal/store"
 	"notification-service/internal/websocket"
+	"notification-service/shared/middleware"
 	"notification-service/shared/response"
 )
 
 type NotificationHandler struct {
-	hub *websocket.Hub
+	hub   *websocket.Hub
+	store store.NotificationStore
 }
 
-func NewNotificationHandler(hub *websocket.Hub) *NotificationHandler {
+func NewNotificationHandler(hub *websocket.Hub, notificationStore store.NotificationStore) *NotificationHandler {
 	return &NotificationHandler{
-		hub: hub,
+		hub:   hub,
+		store: notificationStore,
 	}
 }
 
 func (h *NotificationHandler) SendNotification(c *gin.Context) {
 	var req models.NotifyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		zap.L().Error("Invalid notification request", zap.Error(err))
+		middleware.RequestLogger(c).Error("Invalid notification request", zap.Error(err))
 		response.BadRequest(c, "Invalid request payload")
 		return
 	}
 
+	if req.Topic != "" {
+		if req.UserID != "" {
+			response.BadRequest(c, "Specify either userId or topic, not both")
+			return
+		}
+		if !websocket.ValidTopic(req.Topic) {
+			response.BadRequest(c, "Topic must look like kind:id")
+			return
+		}
+	}
+
 	notification := &models.Notification{
 		ID:        uuid.New().String(),
 		UserID:    req.UserID,
+		Topic:     req.Topic,
 		Type:      req.Type,
 		Title:     req.Title,
 		Message:   req.Message,
@@ -40,22 +57,43 @@ func (h *NotificationHandler) SendNotification(c *gin.Context) {
 		Timestamp: time.Now(),
 	}
 
-	if req.UserID != "" {
-		h.hub.BroadcastToUser(req.UserID, notification)
-	} else {
-		h.hub.BroadcastToAll(notification)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.store.Save(ctx, notification); err != nil {
+		middleware.RequestLogger(c).Error("Failed to store notification", zap.Error(err))
+		response.InternalError(c, "Failed to store notification")
+		return
 	}
 
-	zap.L().Info("Notification sent",
+	status := "sent"
+	var err error
+	switch {
+	case req.Topic != "":
+		err = h.hub.BroadcastToTopic(ctx, req.Topic, notification)
+	case req.UserID != "":
+		err = h.hub.BroadcastToUser(ctx, req.UserID, notification)
+	default:
+		err = h.hub.BroadcastToAll(ctx, notification)
+	}
+	if err != nil {
+		// The notification is stored, so the user still sees it in their inbox
+		middleware.RequestLogger(c).Error("Failed to publish notification", zap.Error(err))
+		status = "stored"
+	}
+
+	middleware.RequestLogger(c).Info("Notification sent",
 		zap.String("id", notification.ID),
 		zap.String("type", notification.Type),
 		zap.String("userID", notification.UserID),
+		zap.String("topic", notification.Topic),
+		zap.String("status", status),
 	)
 
 	response.Success(c, gin.H{
 		"id":        notification.ID,
 		"timestamp": notification.Timestamp,
-		"status":    "sent",
+		"status":    status,
 	})
 }
 
@@ -74,6 +112,11 @@ func (h *NotificationHandler) GetActiveConnections(c *gin.Context) {
 		stats["userId"] = userID
 	}
 
+	if topic := c.Query("topic"); topic != "" {
+		stats["topicSubscribers"] = h.hub.GetTopicSubscriberCount(topic)
+		stats["topic"] = topic
+	}
+
 	response.Success(c, stats)
 }
 
@@ -5,20 +5,24 @@ import (
 )
 
 type Notification struct {
-	ID        string                 `json:"id"`
-	UserID    string                 `json:"userId,omitempty"`
-	Type      string                 `json:"type"`
-	Title     string                 `json:"title"`
-	Message   string                 `json:"message"`
-	Data      map[string]interface{} `json:"data,omitempty"`
-	Timestamp time.Time              `json:"timestamp"`
+	ID        string                 `json:"id" bson:"_id"`
+	UserID    string                 `json:"userId,omitempty" bson:"userId,omitempty"`
+	Topic     string                 `json:"topic,omitempty" bson:"topic,omitempty"`
+	Type      string                 `json:"type" bson:"type"`
+	Title     string                 `json:"title" bson:"title"`
+	Message   string                 `json:"message" bson:"message"`
+	Data      map[string]interface{} `json:"data,omitempty" bson:"data,omitempty"`
+	Read      bool                   `json:"read" bson:"read"`
+	ReadAt    *time.Time             `json:"readAt,omitempty" bson:"readAt,omitempty"`
+	Timestamp time.Time              `json:"timestamp" bson:"timestamp"`
+	Seq       uint64                 `json:"seq,omitempty" bson:"-"`
 }
 
 type NotifyRequest struct {
 	UserID  string                 `json:"userId,omitempty" binding:"omitempty,uuid"`
+	Topic   string                 `json:"topic,omitempty" binding:"omitempty,max=128"`
 	Type    string                 `json:"type" binding:"required"`
 	Title   string                 `json:"title" binding:"required"`
 	Message string                 `json:"message" binding:"required"`
 	Data    map[string]interface{} `json:"data,omitempty"`
 }
-
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"notification-service/internal/models"
+)
+
+// MemoryStore keeps notifications in process memory. It is intended for
+// tests and local development where MongoDB is not available.
+type MemoryStore struct {
+	notifications map[string]*models.Notification
+
+	// Read times of shared notifications, by notification and user ID.
+	reads map[string]map[string]time.Time
+	mutex sync.RWMutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		notifications: make(map[string]*models.Notification),
+		reads:         make(map[string]map[string]time.Time),
+	}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, notification *models.Notification) error {
+	stored := *notification
+
+	s.mutex.Lock()
+	s.notifications[stored.ID] = &stored
+	s.mutex.Unlock()
+
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, opts ListOptions) ([]*models.Notification, int64, error) {
+	s.mutex.RLock()
+	matched := make([]*models.Notification, 0)
+	for _, n := range s.notifications {
+		if n.UserID != opts.UserID || (opts.UnreadOnly && n.Read) {
+			continue
+		}
+		copied := *n
+		matched = append(matched, &copied)
+	}
+	s.mutex.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Timestamp.After(matched[j].Timestamp)
+	})
+
+	total := int64(len(matched))
+	start := (opts.Page - 1) * opts.Limit
+	if start >= len(matched) {
+		return []*models.Notification{}, total, nil
+	}
+	end := start + opts.Limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return matched[start:end], total, nil
+}
+
+func (s *MemoryStore) MarkAsRead(ctx context.Context, notificationID, userID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	n, exists := s.notifications[notificationID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	if n.UserID == "" {
+		if s.reads[notificationID] == nil {
+			s.reads[notificationID] = make(map[string]time.Time)
+		}
+		if _, read := s.reads[notificationID][userID]; !read {
+			s.reads[notificationID][userID] = time.Now()
+		}
+		return nil
+	}
+
+	if n.UserID != userID {
+		return ErrNotFound
+	}
+
+	if !n.Read {
+		now := time.Now()
+		n.Read = true
+		n.ReadAt = &now
+	}
+	return nil
+}
+
+func (s *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (s *MemoryStore) Close(ctx context.Context) error {
+	return nil
+}
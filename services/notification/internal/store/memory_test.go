@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"notification-service/internal/models"
+)
+
+func seedMemoryStore(t *testing.T) *MemoryStore {
+	t.Helper()
+
+	s := NewMemoryStore()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notifications := []*models.Notification{
+		{ID: "a1", UserID: "alice", Timestamp: base.Add(1 * time.Minute)},
+		{ID: "a2", UserID: "alice", Timestamp: base.Add(2 * time.Minute), Read: true},
+		{ID: "a3", UserID: "alice", Timestamp: base.Add(3 * time.Minute)},
+		{ID: "a4", UserID: "alice", Timestamp: base.Add(4 * time.Minute)},
+		{ID: "b1", UserID: "bob", Timestamp: base.Add(5 * time.Minute)},
+		{ID: "t1", Topic: "contract:c1", Timestamp: base.Add(6 * time.Minute)},
+		{ID: "all1", Timestamp: base.Add(7 * time.Minute)},
+	}
+	for _, n := range notifications {
+		if err := s.Save(context.Background(), n); err != nil {
+			t.Fatalf("Save(%s): %v", n.ID, err)
+		}
+	}
+	return s
+}
+
+func TestMemoryStoreList(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      ListOptions
+		wantIDs   []string
+		wantTotal int64
+	}{
+		{
+			name:      "newest first",
+			opts:      ListOptions{UserID: "alice", Page: 1, Limit: 10},
+			wantIDs:   []string{"a4", "a3", "a2", "a1"},
+			wantTotal: 4,
+		},
+		{
+			name:      "unread only",
+			opts:      ListOptions{UserID: "alice", Page: 1, Limit: 10, UnreadOnly: true},
+			wantIDs:   []string{"a4", "a3", "a1"},
+			wantTotal: 3,
+		},
+		{
+			name:      "second page",
+			opts:      ListOptions{UserID: "alice", Page: 2, Limit: 3},
+			wantIDs:   []string{"a1"},
+			wantTotal: 4,
+		},
+		{
+			name:      "page past the end",
+			opts:      ListOptions{UserID: "alice", Page: 3, Limit: 3},
+			wantIDs:   []string{},
+			wantTotal: 4,
+		},
+		{
+			name:      "other user",
+			opts:      ListOptions{UserID: "bob", Page: 1, Limit: 10},
+			wantIDs:   []string{"b1"},
+			wantTotal: 1,
+		},
+		{
+			name:      "unknown user",
+			opts:      ListOptions{UserID: "carol", Page: 1, Limit: 10},
+			wantIDs:   []string{},
+			wantTotal: 0,
+		},
+	}
+
+	s := seedMemoryStore(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := s.List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", total, tt.wantTotal)
+			}
+
+			ids := make([]string, 0, len(got))
+			for _, n := range got {
+				ids = append(ids, n.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreMarkAsRead(t *testing.T) {
+	tests := []struct {
+		name           string
+		notificationID string
+		userID         string
+		wantErr        error
+	}{
+		{name: "unread", notificationID: "a1", userID: "alice"},
+		{name: "already read", notificationID: "a2", userID: "alice"},
+		{name: "topic notification", notificationID: "t1", userID: "alice"},
+		{name: "broadcast notification", notificationID: "all1", userID: "alice"},
+		{name: "other user's notification", notificationID: "b1", userID: "alice", wantErr: ErrNotFound},
+		{name: "unknown notification", notificationID: "zz", userID: "alice", wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := seedMemoryStore(t)
+
+			err := s.MarkAsRead(context.Background(), tt.notificationID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MarkAsRead = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			n := s.notifications[tt.notificationID]
+			if n.UserID == "" {
+				if _, read := s.reads[tt.notificationID][tt.userID]; !read {
+					t.Error("no read marker for the user")
+				}
+				if n.Read {
+					t.Error("shared notification is read for everyone")
+				}
+				return
+			}
+			if !n.Read {
+				t.Error("notification is not read")
+			}
+		})
+	}
+}
+
+func TestMemoryStoreMarkAsReadKeepsReadTime(t *testing.T) {
+	s := seedMemoryStore(t)
+	ctx := context.Background()
+
+	if err := s.MarkAsRead(ctx, "a1", "alice"); err != nil {
+		t.Fatalf("MarkAsRead: %v", err)
+	}
+	readAt := *s.notifications["a1"].ReadAt
+
+	if err := s.MarkAsRead(ctx, "a1", "alice"); err != nil {
+		t.Fatalf("second MarkAsRead: %v", err)
+	}
+	if got := *s.notifications["a1"].ReadAt; !got.Equal(readAt) {
+		t.Errorf("ReadAt changed from %v to %v", readAt, got)
+	}
+}
+
+func TestMemoryStoreCopies(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	n := &models.Notification{ID: "n1", UserID: "alice", Title: "saved"}
+	if err := s.Save(ctx, n); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	n.Title = "changed after save"
+
+	got, _, err := s.List(ctx, ListOptions{UserID: "alice", Page: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	got[0].Title = "changed after list"
+
+	if title := s.notifications["n1"].Title; title != "saved" {
+		t.Errorf("stored title = %q, want %q", title, "saved")
+	}
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"notification-service/internal/models"
+)
+
+const (
+	DatabaseName                = "notification_db"
+	NotificationsCollectionName = "notifications"
+	ReadsCollectionName         = "notification_reads"
+)
+
+type MongoStore struct {
+	client        *mongo.Client
+	notifications *mongo.Collection
+	reads         *mongo.Collection
+}
+
+// readMarkerID identifies the record of a user having read a notification
+// sent to a topic or to everyone.
+type readMarkerID struct {
+	NotificationID string `bson:"notificationId"`
+	UserID         string `bson:"userId"`
+}
+
+func NewMongoStore(mongoURI string) (*MongoStore, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Connect to MongoDB
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	// Test the connection
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
+	}
+
+	s := &MongoStore{
+		client:        client,
+		notifications: client.Database(DatabaseName).Collection(NotificationsCollectionName),
+		reads:         client.Database(DatabaseName).Collection(ReadsCollectionName),
+	}
+
+	if err := s.createIndexes(); err != nil {
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	zap.L().Info("Connected to MongoDB successfully",
+		zap.String("database", DatabaseName),
+	)
+
+	return s, nil
+}
+
+func (s *MongoStore) createIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "userId", Value: 1},
+				{Key: "read", Value: 1},
+				{Key: "timestamp", Value: -1},
+			},
+		},
+	}
+
+	_, err := s.notifications.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+func (s *MongoStore) Save(ctx context.Context, notification *models.Notification) error {
+	if _, err := s.notifications.InsertOne(ctx, notification); err != nil {
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+	return nil
+}
+
+func (s *MongoStore) List(ctx context.Context, opts ListOptions) ([]*models.Notification, int64, error) {
+	filter := bson.M{"userId": opts.UserID}
+	if opts.UnreadOnly {
+		filter["read"] = false
+	}
+
+	total, err := s.notifications.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetSkip(int64((opts.Page - 1) * opts.Limit)).
+		SetLimit(int64(opts.Limit))
+
+	cursor, err := s.notifications.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find notifications: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	notifications := make([]*models.Notification, 0, opts.Limit)
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+func (s *MongoStore) MarkAsRead(ctx context.Context, notificationID, userID string) error {
+	// Only unread notifications are updated so the original read time is kept
+	unread := bson.M{"_id": notificationID, "userId": userID, "read": false}
+	update := bson.M{"$set": bson.M{"read": true, "readAt": time.Now()}}
+	result, err := s.notifications.UpdateOne(ctx, unread, update)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	var owner struct {
+		UserID string `bson:"userId"`
+	}
+	err = s.notifications.FindOne(ctx, bson.M{"_id": notificationID},
+		options.FindOne().SetProjection(bson.M{"userId": 1}),
+	).Decode(&owner)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up notification: %w", err)
+	}
+
+	switch owner.UserID {
+	case userID:
+		// Already read
+		return nil
+	case "":
+		return s.markSharedAsRead(ctx, notificationID, userID)
+	default:
+		return ErrNotFound
+	}
+}
+
+// markSharedAsRead upserts the user's read marker of a topic or broadcast
+// notification, keeping the time of the first read.
+func (s *MongoStore) markSharedAsRead(ctx context.Context, notificationID, userID string) error {
+	_, err := s.reads.UpdateOne(ctx,
+		bson.M{"_id": readMarkerID{NotificationID: notificationID, UserID: userID}},
+		bson.M{"$setOnInsert": bson.M{"readAt": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+func (s *MongoStore) Ping(ctx context.Context) error {
+	if err := s.client.Ping(ctx, nil); err != nil {
+		return fmt.Errorf("failed to ping MongoDB: %w", err)
+	}
+	return nil
+}
+
+func (s *MongoStore) Close(ctx context.Context) error {
+	if err := s.client.Disconnect(ctx); err != nil {
+		return fmt.Errorf("failed to disconnect from MongoDB: %w", err)
+	}
+
+	zap.L().Info("Disconnected from MongoDB")
+	return nil
+}
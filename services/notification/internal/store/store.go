@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"notification-service/internal/models"
+)
+
+// ErrNotFound is returned when a notification does not exist or does not
+// belong to the requesting user.
+var ErrNotFound = errors.New("notification not found")
+
+type ListOptions struct {
+	UserID     string
+	Page       int
+	Limit      int
+	UnreadOnly bool
+}
+
+// NotificationStore persists notifications so they can be listed and marked
+// as read after they have been pushed to connected clients.
+type NotificationStore interface {
+	Save(ctx context.Context, notification *models.Notification) error
+	List(ctx context.Context, opts ListOptions) ([]*models.Notification, int64, error)
+	// MarkAsRead marks a notification of the user as read. Notifications
+	// sent to a topic or to everyone have no single owner, so they get a
+	// read marker for the acknowledging user instead.
+	MarkAsRead(ctx context.Context, notificationID, userID string) error
+
+	// Ping returns an error while the store cannot be reached.
+	Ping(ctx context.Context) error
+
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,143 @@
+// Package topics decides who may subscribe to a notification topic. Topics
+// name a contract or dispute, and only the users involved in it may receive
+// its events.
+package topics
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"notification-service/internal/auth"
+	contractpb "notification-service/internal/grpc/contract/proto"
+	disputepb "notification-service/internal/grpc/dispute/proto"
+	"notification-service/shared/grpcmeta"
+)
+
+// Topic kinds, the part before the colon of "kind:id".
+const (
+	KindContract = "contract"
+	KindDispute  = "dispute"
+)
+
+// Mediators may follow every dispute, as they may resolve any of them.
+const (
+	roleAdmin                = "admin"
+	roleMediator             = "mediator"
+	permissionDisputeResolve = "dispute:resolve"
+)
+
+// Time allowed to look up a contract or dispute.
+const lookupTimeout = 5 * time.Second
+
+// ErrForbidden is returned when the user is not a member of the topic, the
+// topic's resource does not exist or the topic kind is unknown.
+var ErrForbidden = errors.New("not allowed to subscribe to topic")
+
+// Authorizer decides whether a user may subscribe to a topic. It returns
+// ErrForbidden to reject the subscription and any other error when
+// membership could not be checked.
+type Authorizer interface {
+	Authorize(ctx context.Context, claims *auth.Claims, topic string) error
+}
+
+// MembershipAuthorizer checks topic membership against the contract and
+// dispute services: contract topics are open to the contract's client and
+// freelancer, dispute topics to the dispute's parties, its resolver and
+// mediators.
+type MembershipAuthorizer struct {
+	contracts contractpb.ContractServiceClient
+	disputes  disputepb.DisputeServiceClient
+}
+
+func NewMembershipAuthorizer(contracts contractpb.ContractServiceClient, disputes disputepb.DisputeServiceClient) *MembershipAuthorizer {
+	return &MembershipAuthorizer{
+		contracts: contracts,
+		disputes:  disputes,
+	}
+}
+
+func (a *MembershipAuthorizer) Authorize(ctx context.Context, claims *auth.Claims, topic string) error {
+	kind, id, ok := strings.Cut(topic, ":")
+	if !ok || id == "" {
+		return ErrForbidden
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+	ctx = grpcmeta.NewOutgoingContext(ctx, grpcmeta.Caller{
+		UserID: claims.UserID,
+		Email:  claims.Email,
+		Roles:  claims.Roles,
+	})
+
+	switch kind {
+	case KindContract:
+		return a.authorizeContract(ctx, claims, id)
+	case KindDispute:
+		return a.authorizeDispute(ctx, claims, id)
+	default:
+		return ErrForbidden
+	}
+}
+
+func (a *MembershipAuthorizer) authorizeContract(ctx context.Context, claims *auth.Claims, contractID string) error {
+	resp, err := a.contracts.GetContract(ctx, &contractpb.GetContractRequest{
+		ContractId: contractID,
+		UserId:     claims.UserID,
+	})
+	if err != nil {
+		return lookupError(err)
+	}
+	if !resp.Success || resp.Data == nil {
+		return ErrForbidden
+	}
+
+	if resp.Data.ClientId != claims.UserID && resp.Data.FreelancerId != claims.UserID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (a *MembershipAuthorizer) authorizeDispute(ctx context.Context, claims *auth.Claims, disputeID string) error {
+	resp, err := a.disputes.GetDispute(ctx, &disputepb.GetDisputeRequest{
+		DisputeId: disputeID,
+		UserId:    claims.UserID,
+	})
+	if err != nil {
+		return lookupError(err)
+	}
+	if !resp.Success || resp.Data == nil {
+		return ErrForbidden
+	}
+
+	switch claims.UserID {
+	case resp.Data.ClientId, resp.Data.FreelancerId, resp.Data.ResolverId:
+		return nil
+	}
+	if isMediator(claims) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+func isMediator(claims *auth.Claims) bool {
+	return slices.Contains(claims.Roles, roleMediator) ||
+		slices.Contains(claims.Roles, roleAdmin) ||
+		slices.Contains(claims.Permissions, permissionDisputeResolve)
+}
+
+// lookupError treats a resource the backend does not find or hides from the
+// user like one they are not a member of.
+func lookupError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound, codes.PermissionDenied, codes.InvalidArgument:
+		return ErrForbidden
+	}
+	return err
+}
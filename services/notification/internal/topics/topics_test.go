@@ -0,0 +1,102 @@
+package topics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"notification-service/internal/auth"
+	contractpb "notification-service/internal/grpc/contract/proto"
+	disputepb "notification-service/internal/grpc/dispute/proto"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "connection refused")
+
+type fakeContracts struct {
+	contractpb.ContractServiceClient
+	contracts map[string]*contractpb.ContractData
+	err       error
+}
+
+func (f *fakeContracts) GetContract(_ context.Context, req *contractpb.GetContractRequest, _ ...grpc.CallOption) (*contractpb.GetContractResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	contract, ok := f.contracts[req.ContractId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "contract not found")
+	}
+	return &contractpb.GetContractResponse{Success: true, Data: contract}, nil
+}
+
+type fakeDisputes struct {
+	disputepb.DisputeServiceClient
+	disputes map[string]*disputepb.DisputeData
+	err      error
+}
+
+func (f *fakeDisputes) GetDispute(_ context.Context, req *disputepb.GetDisputeRequest, _ ...grpc.CallOption) (*disputepb.GetDisputeResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	dispute, ok := f.disputes[req.DisputeId]
+	if !ok {
+		return &disputepb.GetDisputeResponse{Success: false, Error: "NOT_FOUND"}, nil
+	}
+	return &disputepb.GetDisputeResponse{Success: true, Data: dispute}, nil
+}
+
+func TestMembershipAuthorizer(t *testing.T) {
+	contracts := &fakeContracts{contracts: map[string]*contractpb.ContractData{
+		"c1": {Id: "c1", ClientId: "alice", FreelancerId: "bob"},
+	}}
+	disputes := &fakeDisputes{disputes: map[string]*disputepb.DisputeData{
+		"d1": {Id: "d1", ClientId: "alice", FreelancerId: "bob", ResolverId: "mia"},
+	}}
+
+	tests := []struct {
+		name    string
+		claims  auth.Claims
+		topic   string
+		wantErr error
+	}{
+		{name: "contract client", claims: auth.Claims{UserID: "alice"}, topic: "contract:c1"},
+		{name: "contract freelancer", claims: auth.Claims{UserID: "bob"}, topic: "contract:c1"},
+		{name: "contract outsider", claims: auth.Claims{UserID: "eve"}, topic: "contract:c1", wantErr: ErrForbidden},
+		{name: "mediator on a contract", claims: auth.Claims{UserID: "mia", Roles: []string{"mediator"}}, topic: "contract:c1", wantErr: ErrForbidden},
+		{name: "unknown contract", claims: auth.Claims{UserID: "alice"}, topic: "contract:c2", wantErr: ErrForbidden},
+		{name: "dispute party", claims: auth.Claims{UserID: "bob"}, topic: "dispute:d1"},
+		{name: "dispute resolver", claims: auth.Claims{UserID: "mia"}, topic: "dispute:d1"},
+		{name: "mediator role", claims: auth.Claims{UserID: "max", Roles: []string{"mediator"}}, topic: "dispute:d1"},
+		{name: "admin role", claims: auth.Claims{UserID: "root", Roles: []string{"admin"}}, topic: "dispute:d1"},
+		{name: "resolve permission", claims: auth.Claims{UserID: "max", Permissions: []string{"dispute:resolve"}}, topic: "dispute:d1"},
+		{name: "dispute outsider", claims: auth.Claims{UserID: "eve"}, topic: "dispute:d1", wantErr: ErrForbidden},
+		{name: "unknown dispute", claims: auth.Claims{UserID: "alice"}, topic: "dispute:d2", wantErr: ErrForbidden},
+		{name: "unknown kind", claims: auth.Claims{UserID: "alice"}, topic: "payment:p1", wantErr: ErrForbidden},
+	}
+
+	a := NewMembershipAuthorizer(contracts, disputes)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.Authorize(context.Background(), &tt.claims, tt.topic)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authorize(%s) = %v, want %v", tt.topic, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMembershipAuthorizerLookupFailure(t *testing.T) {
+	a := NewMembershipAuthorizer(&fakeContracts{err: errUnavailable}, &fakeDisputes{err: errUnavailable})
+
+	for _, topic := range []string{"contract:c1", "dispute:d1"} {
+		err := a.Authorize(context.Background(), &auth.Claims{UserID: "alice"}, topic)
+		if err == nil || errors.Is(err, ErrForbidden) {
+			t.Errorf("Authorize(%s) = %v, want the lookup error", topic, err)
+		}
+	}
+}
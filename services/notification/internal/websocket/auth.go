@@ -0,0 +1,96 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"notification-service/internal/auth"
+	"notification-service/shared/jwtauth"
+)
+
+const (
+	// Subprotocol announcing that the next offered subprotocol is a bearer
+	// token, e.g. new WebSocket(url, ["bearer", token]).
+	bearerSubprotocol = "bearer"
+
+	// Time allowed for the client to send its auth message.
+	authWait = 10 * time.Second
+
+	// Maximum size of the auth message.
+	maxAuthMessageSize = 4096
+)
+
+var errAuthRequired = errors.New("first message must be an auth message with a token")
+
+type authMessage struct {
+	Type  string `json:"type"`
+	Token string `json:"token"`
+}
+
+// handshakeToken returns the token sent with the upgrade request, either in
+// the Authorization header or as the subprotocol following "bearer".
+func handshakeToken(r *http.Request) (string, bool) {
+	if token, ok := jwtauth.BearerToken(r.Header.Get("Authorization")); ok {
+		return token, true
+	}
+
+	protocols := websocket.Subprotocols(r)
+	for i, protocol := range protocols {
+		if protocol == bearerSubprotocol && i+1 < len(protocols) {
+			return protocols[i+1], true
+		}
+	}
+
+	return "", false
+}
+
+// authenticateFirstMessage waits for an {"type":"auth","token":"..."} message
+// on a connection that did not carry a token in the handshake.
+func authenticateFirstMessage(ctx context.Context, conn *websocket.Conn, validator *auth.TokenValidator) (*auth.Claims, error) {
+	conn.SetReadLimit(maxAuthMessageSize)
+	conn.SetReadDeadline(time.Now().Add(authWait))
+
+	var msg authMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		return nil, err
+	}
+
+	if msg.Type != MessageTypeAuth || msg.Token == "" {
+		return nil, errAuthRequired
+	}
+
+	return validator.Validate(ctx, msg.Token)
+}
+
+// newOriginChecker builds the upgrader's CheckOrigin from an allowlist.
+// An empty allowlist keeps gorilla's same-origin check and "*" allows any
+// origin.
+func newOriginChecker(allowedOrigins []string) func(r *http.Request) bool {
+	if len(allowedOrigins) == 0 {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimRight(origin, "/"))] = true
+	}
+
+	return func(r *http.Request) bool {
+		if allowed["*"] {
+			return true
+		}
+
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			// Non-browser clients do not send an Origin header
+			return true
+		}
+
+		return allowed[strings.ToLower(origin)]
+	}
+}
@@ -1,140 +1,371 @@
 package websocket
 
 import (
-	"encoding/json"
-	"net/http"
+	"context"
+	"errors"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 
+	"notification-service/internal/auth"
+	"notification-service/internal/backplane"
 	"notification-service/internal/models"
+	"notification-service/internal/store"
+	"notification-service/internal/topics"
+	"notification-service/shared/middleware"
+	"notification-service/shared/response"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow connections from any origin
-		return true
-	},
-}
-
 type Client struct {
 	hub      *Hub
 	conn     *websocket.Conn
 	send     chan []byte
 	userID   string
+	claims   *auth.Claims
 	clientID string
+	replay   replayCursor
+	topics   map[string]bool
+
+	// logger adds the ID of the request that opened the connection to
+	// every line logged about the client.
+	logger *zap.Logger
+
+	// requests holds the subscribe, unsubscribe and ack frames waiting for
+	// processRequests, so slow lookups never stall the read loop. ctx is
+	// canceled when the connection closes.
+	requests chan *Envelope
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type HubConfig struct {
+	// Validator checks the JWT presented by connecting clients.
+	Validator *auth.TokenValidator
+
+	// AllowedOrigins lists the browser origins allowed to connect.
+	// Empty means same-origin only and "*" allows any origin.
+	AllowedOrigins []string
+
+	// Backplane fans notifications out to all replicas. Defaults to an
+	// in-process backplane for single-replica deployments.
+	Backplane backplane.Backplane
+
+	// Store marks notifications acknowledged by clients as read. It is
+	// required.
+	Store store.NotificationStore
+
+	// Topics decides which topics a user may subscribe to. Without it
+	// every subscription is rejected.
+	Topics topics.Authorizer
 }
 
 type Hub struct {
-	clients     map[*Client]bool
-	register    chan *Client
-	unregister  chan *Client
-	broadcast   chan []byte
-	userClients map[string][]*Client
-	mutex       sync.RWMutex
+	clients      map[*Client]bool
+	register     chan *Client
+	unregister   chan *Client
+	broadcast    chan []byte
+	userClients  map[string][]*Client
+	topicClients map[string]map[*Client]bool
+	queues       map[string]*userQueue
+	mutex        sync.RWMutex
+	upgrader     websocket.Upgrader
+	validator    *auth.TokenValidator
+	backplane    backplane.Backplane
+	store        store.NotificationStore
+	topics       topics.Authorizer
 }
 
-func NewHub() *Hub {
-	return &Hub{
-		clients:     make(map[*Client]bool),
-		register:    make(chan *Client),
-		unregister:  make(chan *Client),
-		broadcast:   make(chan []byte),
-		userClients: make(map[string][]*Client),
+func NewHub(config HubConfig) (*Hub, error) {
+	if config.Store == nil {
+		return nil, errors.New("hub requires a notification store")
+	}
+	if config.Backplane == nil {
+		config.Backplane = backplane.NewInProcessBackplane()
 	}
+
+	h := &Hub{
+		clients:      make(map[*Client]bool),
+		register:     make(chan *Client),
+		unregister:   make(chan *Client),
+		broadcast:    make(chan []byte),
+		userClients:  make(map[string][]*Client),
+		topicClients: make(map[string]map[*Client]bool),
+		queues:       make(map[string]*userQueue),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			Subprotocols:    []string{bearerSubprotocol},
+			CheckOrigin:     newOriginChecker(config.AllowedOrigins),
+		},
+		validator: config.Validator,
+		backplane: config.Backplane,
+		store:     config.Store,
+		topics:    config.Topics,
+	}
+
+	if err := h.backplane.Subscribe(h.deliver); err != nil {
+		return nil, err
+	}
+
+	return h, nil
 }
 
 func (h *Hub) Run() {
+	ticker := time.NewTicker(queuePruneInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case client := <-h.register:
+			replayed := 0
 			h.mutex.Lock()
 			h.clients[client] = true
 			if client.userID != "" {
 				h.userClients[client.userID] = append(h.userClients[client.userID], client)
+				replayed = h.replayQueued(client)
 			}
 			h.mutex.Unlock()
 
-			zap.L().Info("Client registered",
+			client.logger.Info("Client registered",
 				zap.String("clientID", client.clientID),
 				zap.String("userID", client.userID),
+				zap.Int("replayed", replayed),
 				zap.Int("totalClients", len(h.clients)),
 			)
 
 		case client := <-h.unregister:
 			h.mutex.Lock()
 			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
-
-				// Remove from user clients
-				if client.userID != "" {
-					userClients := h.userClients[client.userID]
-					for i, c := range userClients {
-						if c == client {
-							h.userClients[client.userID] = append(userClients[:i], userClients[i+1:]...)
-							break
-						}
-					}
-					if len(h.userClients[client.userID]) == 0 {
-						delete(h.userClients, client.userID)
-					}
-				}
+				h.removeClient(client)
 			}
 			h.mutex.Unlock()
 
-			zap.L().Info("Client unregistered",
+			client.logger.Info("Client unregistered",
 				zap.String("clientID", client.clientID),
 				zap.String("userID", client.userID),
 				zap.Int("totalClients", len(h.clients)),
 			)
 
 		case message := <-h.broadcast:
-			h.mutex.RLock()
+			h.mutex.Lock()
 			for client := range h.clients {
 				select {
 				case client.send <- message:
 				default:
-					close(client.send)
-					delete(h.clients, client)
+					h.removeClient(client)
 				}
 			}
-			h.mutex.RUnlock()
+			h.mutex.Unlock()
+
+		case <-ticker.C:
+			h.pruneQueues()
+		}
+	}
+}
+
+// removeClient drops a client from the hub and closes its send channel.
+// The caller must hold the write lock.
+func (h *Hub) removeClient(client *Client) {
+	delete(h.clients, client)
+	close(client.send)
+
+	for topic := range client.topics {
+		h.removeFromTopic(client, topic)
+	}
+
+	if client.userID == "" {
+		return
+	}
+
+	userClients := h.userClients[client.userID]
+	for i, c := range userClients {
+		if c == client {
+			h.userClients[client.userID] = append(userClients[:i], userClients[i+1:]...)
+			break
+		}
+	}
+	if len(h.userClients[client.userID]) == 0 {
+		delete(h.userClients, client.userID)
+	}
+}
+
+// replayQueued sends the queued notifications the client has not seen yet.
+// The caller must hold the write lock.
+func (h *Hub) replayQueued(client *Client) int {
+	queue, exists := h.queues[client.userID]
+	if !exists {
+		return 0
+	}
+
+	queue.prune(time.Now())
+
+	replayed := 0
+	for _, m := range queue.pending(client.replay) {
+		select {
+		case client.send <- m.message:
+			replayed++
+		default:
+			client.logger.Warn("Client send buffer full, replay truncated",
+				zap.String("clientID", client.clientID),
+				zap.String("userID", client.userID),
+			)
+			return replayed
 		}
 	}
+	return replayed
 }
 
-func (h *Hub) BroadcastToAll(notification *models.Notification) {
-	message, err := json.Marshal(notification)
+func (h *Hub) pruneQueues() {
+	now := time.Now()
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for userID, queue := range h.queues {
+		queue.prune(now)
+		if len(queue.messages) == 0 && len(h.userClients[userID]) == 0 {
+			delete(h.queues, userID)
+		}
+	}
+}
+
+// BroadcastToAll publishes a notification for every connected client on all
+// replicas.
+func (h *Hub) BroadcastToAll(ctx context.Context, notification *models.Notification) error {
+	return h.backplane.Publish(ctx, &backplane.Message{
+		Notification: notification,
+		RequestID:    middleware.RequestIDFromContext(ctx),
+	})
+}
+
+// BroadcastToUser assigns the user's next sequence number to the
+// notification and publishes it to the replicas holding their connections.
+func (h *Hub) BroadcastToUser(ctx context.Context, userID string, notification *models.Notification) error {
+	seq, err := h.backplane.NextSequence(ctx, userID)
 	if err != nil {
-		zap.L().Error("Failed to marshal notification", zap.Error(err))
+		return err
+	}
+	notification.Seq = seq
+
+	return h.backplane.Publish(ctx, &backplane.Message{
+		UserID:       userID,
+		Notification: notification,
+		RequestID:    middleware.RequestIDFromContext(ctx),
+	})
+}
+
+// BroadcastToTopic publishes a notification for every client subscribed to
+// the topic on all replicas.
+func (h *Hub) BroadcastToTopic(ctx context.Context, topic string, notification *models.Notification) error {
+	return h.backplane.Publish(ctx, &backplane.Message{
+		Topic:        topic,
+		Notification: notification,
+		RequestID:    middleware.RequestIDFromContext(ctx),
+	})
+}
+
+// deliver hands a message received from the backplane to the clients
+// connected to this replica.
+func (h *Hub) deliver(msg *backplane.Message) {
+	if msg.Notification == nil {
+		return
+	}
+
+	logger := zap.L()
+	if msg.RequestID != "" {
+		logger = logger.With(zap.String("request_id", msg.RequestID))
+	}
+
+	switch {
+	case msg.Topic != "":
+		h.deliverToTopic(logger, msg.Topic, msg.Notification)
+	case msg.UserID != "":
+		h.deliverToUser(logger, msg.UserID, msg.Notification)
+	default:
+		h.deliverToAll(logger, msg.Notification)
+	}
+}
+
+func (h *Hub) deliverToTopic(logger *zap.Logger, topic string, notification *models.Notification) {
+	message, err := notificationFrame(notification)
+	if err != nil {
+		logger.Error("Failed to marshal notification", zap.Error(err))
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	subscribers := make([]*Client, 0, len(h.topicClients[topic]))
+	for client := range h.topicClients[topic] {
+		subscribers = append(subscribers, client)
+	}
+
+	for _, client := range subscribers {
+		select {
+		case client.send <- message:
+		default:
+			h.removeClient(client)
+		}
+	}
+
+	logger.Info("Broadcast notification to topic",
+		zap.String("topic", topic),
+		zap.String("type", notification.Type),
+		zap.String("title", notification.Title),
+		zap.Int("clientCount", len(subscribers)),
+	)
+}
+
+func (h *Hub) deliverToAll(logger *zap.Logger, notification *models.Notification) {
+	message, err := notificationFrame(notification)
+	if err != nil {
+		logger.Error("Failed to marshal notification", zap.Error(err))
 		return
 	}
 
 	h.broadcast <- message
-	zap.L().Info("Broadcast notification to all clients",
+	logger.Info("Broadcast notification to all clients",
 		zap.String("type", notification.Type),
 		zap.String("title", notification.Title),
 	)
 }
 
-func (h *Hub) BroadcastToUser(userID string, notification *models.Notification) {
-	message, err := json.Marshal(notification)
+// deliverToUser sends a notification to every local connection of the user
+// and queues it so it can be replayed when the user reconnects.
+func (h *Hub) deliverToUser(logger *zap.Logger, userID string, notification *models.Notification) {
+	message, err := notificationFrame(notification)
 	if err != nil {
-		zap.L().Error("Failed to marshal notification", zap.Error(err))
+		logger.Error("Failed to marshal notification", zap.Error(err))
 		return
 	}
 
-	h.mutex.RLock()
-	userClients, exists := h.userClients[userID]
-	h.mutex.RUnlock()
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	queue, exists := h.queues[userID]
+	if !exists {
+		queue = &userQueue{}
+		h.queues[userID] = queue
+	}
 
-	if !exists || len(userClients) == 0 {
-		zap.L().Warn("No clients found for user", zap.String("userID", userID))
+	queue.push(&queuedMessage{
+		seq:      notification.Seq,
+		id:       notification.ID,
+		message:  message,
+		queuedAt: time.Now(),
+	})
+
+	userClients := append([]*Client(nil), h.userClients[userID]...)
+	if len(userClients) == 0 {
+		logger.Info("No clients found for user, notification queued",
+			zap.String("userID", userID),
+			zap.Uint64("seq", notification.Seq),
+		)
 		return
 	}
 
@@ -142,14 +373,11 @@ func (h *Hub) BroadcastToUser(userID string, notification *models.Notification)
 		select {
 		case client.send <- message:
 		default:
-			close(client.send)
-			h.mutex.Lock()
-			delete(h.clients, client)
-			h.mutex.Unlock()
+			h.removeClient(client)
 		}
 	}
 
-	zap.L().Info("Broadcast notification to user",
+	logger.Info("Broadcast notification to user",
 		zap.String("userID", userID),
 		zap.String("type", notification.Type),
 		zap.String("title", notification.Title),
@@ -157,6 +385,81 @@ func (h *Hub) BroadcastToUser(userID string, notification *models.Notification)
 	)
 }
 
+// sendTo queues a message for a single client unless it has already been
+// removed from the hub.
+func (h *Hub) sendTo(client *Client, message []byte) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if !h.clients[client] {
+		return
+	}
+
+	select {
+	case client.send <- message:
+	default:
+		client.logger.Warn("Client send buffer full, reply dropped",
+			zap.String("clientID", client.clientID),
+			zap.String("userID", client.userID),
+		)
+	}
+}
+
+// authorizeTopic checks that the user is a member of the topic. It returns
+// topics.ErrForbidden when they are not.
+func (h *Hub) authorizeTopic(ctx context.Context, claims *auth.Claims, topic string) error {
+	if h.topics == nil {
+		return topics.ErrForbidden
+	}
+	return h.topics.Authorize(ctx, claims, topic)
+}
+
+// subscribe adds a topic to the client. It returns false when the client
+// already has the maximum number of topics.
+func (h *Hub) subscribe(client *Client, topic string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if client.topics[topic] {
+		return true
+	}
+	if len(client.topics) >= maxTopicsPerClient {
+		return false
+	}
+
+	client.topics[topic] = true
+	if h.topicClients[topic] == nil {
+		h.topicClients[topic] = make(map[*Client]bool)
+	}
+	h.topicClients[topic][client] = true
+	return true
+}
+
+func (h *Hub) unsubscribe(client *Client, topic string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(client.topics, topic)
+	h.removeFromTopic(client, topic)
+}
+
+// removeFromTopic drops the client from the topic index. The caller must
+// hold the write lock.
+func (h *Hub) removeFromTopic(client *Client, topic string) {
+	delete(h.topicClients[topic], client)
+	if len(h.topicClients[topic]) == 0 {
+		delete(h.topicClients, topic)
+	}
+}
+
+// GetTopicSubscriberCount returns the number of local connections
+// subscribed to the topic.
+func (h *Hub) GetTopicSubscriberCount(topic string) int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.topicClients[topic])
+}
+
 func (h *Hub) GetClients() map[*Client]bool {
 	h.mutex.RLock()
 	defer h.mutex.RUnlock()
@@ -172,29 +475,118 @@ func (h *Hub) GetUserConnectionCount(userID string) int {
 	return 0
 }
 
+// ServeWS upgrades the request and registers the client for the user named
+// by the token's sub claim. The token is taken from the Authorization header,
+// the "bearer" subprotocol or, failing both, the first message on the socket.
 func (h *Hub) ServeWS(c *gin.Context) {
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	logger := middleware.RequestLogger(c)
+
+	var claims *auth.Claims
+	if token, ok := handshakeToken(c.Request); ok {
+		var err error
+		claims, err = h.validator.Validate(c.Request.Context(), token)
+		if err != nil {
+			logger.Warn("WebSocket authentication failed", zap.Error(err))
+			response.Unauthorized(c, "Invalid or expired token")
+			return
+		}
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		zap.L().Error("Failed to upgrade connection", zap.Error(err))
+		logger.Error("Failed to upgrade connection", zap.Error(err))
 		return
 	}
 
-	userID := c.Query("userId")
+	if claims == nil {
+		claims, err = authenticateFirstMessage(c.Request.Context(), conn, h.validator)
+		if err != nil {
+			logger.Warn("WebSocket authentication failed", zap.Error(err))
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "authentication failed"),
+				time.Now().Add(writeWait),
+			)
+			conn.Close()
+			return
+		}
+	}
+
+	userID := claims.UserID
 	clientID := c.Query("clientId")
 	if clientID == "" {
 		clientID = "anonymous"
 	}
 
+	// The request context ends when this handler returns, so the
+	// connection gets its own, canceled by readPump
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.Request.Context()))
+
 	client := &Client{
 		hub:      h,
 		conn:     conn,
 		send:     make(chan []byte, 256),
 		userID:   userID,
+		claims:   claims,
 		clientID: clientID,
+		logger:   logger,
+		replay:   h.skipAcknowledged(c.Request.Context(), userID, parseReplayCursor(c)),
+		topics:   make(map[string]bool),
+		requests: make(chan *Envelope, maxPendingRequests),
+		ctx:      ctx,
+		cancel:   cancel,
 	}
 
 	client.hub.register <- client
 
 	go client.writePump()
 	go client.readPump()
+	go client.processRequests()
+}
+
+// skipAcknowledged adds the notifications the user has already acknowledged
+// to a replay cursor, so another connection's acks are honoured. Acks are
+// kept in the store, which all replicas share.
+func (h *Hub) skipAcknowledged(ctx context.Context, userID string, cursor replayCursor) replayCursor {
+	if !cursor.isSet() || h.store == nil {
+		return cursor
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ackTimeout)
+	defer cancel()
+
+	recent, _, err := h.store.List(ctx, store.ListOptions{
+		UserID: userID,
+		Page:   1,
+		Limit:  maxQueuedPerUser,
+	})
+	if err != nil {
+		middleware.RequestLogger(ctx).Warn("Failed to load acknowledged notifications, replaying all",
+			zap.String("userID", userID),
+			zap.Error(err),
+		)
+		return cursor
+	}
+
+	cursor.acked = make(map[string]bool)
+	for _, n := range recent {
+		if n.Read {
+			cursor.acked[n.ID] = true
+		}
+	}
+	return cursor
+}
+
+// parseReplayCursor reads the last notification the client has seen from the
+// lastSeq or lastId query parameters.
+func parseReplayCursor(c *gin.Context) replayCursor {
+	cursor := replayCursor{lastID: c.Query("lastId")}
+
+	if lastSeq := c.Query("lastSeq"); lastSeq != "" {
+		if seq, err := strconv.ParseUint(lastSeq, 10, 64); err == nil {
+			cursor.lastSeq = seq
+			cursor.hasSeq = true
+		}
+	}
+
+	return cursor
 }
@@ -0,0 +1,313 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"notification-service/internal/backplane"
+	"notification-service/internal/models"
+	"notification-service/internal/store"
+	"notification-service/shared/middleware"
+)
+
+func newTestHub(t *testing.T) *Hub {
+	t.Helper()
+	return newReplica(t, backplane.NewInProcessBackplane(), store.NewMemoryStore())
+}
+
+// newReplica starts a hub sharing the backplane and store with the other
+// replicas created with them.
+func newReplica(t *testing.T, bp backplane.Backplane, notificationStore store.NotificationStore) *Hub {
+	t.Helper()
+
+	h, err := NewHub(HubConfig{Backplane: bp, Store: notificationStore})
+	if err != nil {
+		t.Fatalf("NewHub: %v", err)
+	}
+	go h.Run()
+	return h
+}
+
+// connect registers a client without a socket and waits until the hub has
+// registered it, which includes replaying its queued notifications.
+func connect(t *testing.T, h *Hub, userID string, cursor replayCursor) *Client {
+	t.Helper()
+
+	client := &Client{
+		hub:      h,
+		send:     make(chan []byte, 256),
+		userID:   userID,
+		clientID: "test",
+		logger:   zap.NewNop(),
+		replay:   cursor,
+		topics:   make(map[string]bool),
+	}
+	connections := h.GetUserConnectionCount(userID)
+	h.register <- client
+
+	deadline := time.Now().Add(time.Second)
+	for h.GetUserConnectionCount(userID) == connections {
+		if time.Now().After(deadline) {
+			t.Fatal("client was not registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return client
+}
+
+func disconnect(t *testing.T, h *Hub, client *Client) {
+	t.Helper()
+
+	connections := h.GetUserConnectionCount(client.userID)
+	h.unregister <- client
+
+	deadline := time.Now().Add(time.Second)
+	for h.GetUserConnectionCount(client.userID) == connections {
+		if time.Now().After(deadline) {
+			t.Fatal("client was not unregistered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// received drains the notification frames queued for the client and returns
+// their sequence numbers.
+func received(t *testing.T, client *Client) []uint64 {
+	t.Helper()
+
+	var seqs []uint64
+	for {
+		select {
+		case message, ok := <-client.send:
+			if !ok {
+				return seqs
+			}
+			var frame Envelope
+			if err := json.Unmarshal(message, &frame); err != nil {
+				t.Fatalf("invalid frame %s: %v", message, err)
+			}
+			if frame.Type != MessageTypeNotification || frame.Data == nil {
+				t.Fatalf("unexpected frame %s", message)
+			}
+			seqs = append(seqs, frame.Data.Seq)
+		default:
+			return seqs
+		}
+	}
+}
+
+// sendToUser stores and broadcasts a notification like the gRPC and HTTP
+// handlers do.
+func sendToUser(t *testing.T, h *Hub, userID string) *models.Notification {
+	t.Helper()
+
+	notification := &models.Notification{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      "test",
+		Title:     "Test",
+		Message:   "Test notification",
+		Timestamp: time.Now(),
+	}
+	if err := h.store.Save(context.Background(), notification); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := h.BroadcastToUser(context.Background(), userID, notification); err != nil {
+		t.Fatalf("BroadcastToUser: %v", err)
+	}
+	return notification
+}
+
+func TestHubAssignsSequencePerUser(t *testing.T) {
+	h := newTestHub(t)
+
+	var got []uint64
+	for _, userID := range []string{"alice", "alice", "bob", "alice", "bob"} {
+		got = append(got, sendToUser(t, h, userID).Seq)
+	}
+
+	if want := []uint64{1, 2, 1, 3, 2}; !slices.Equal(got, want) {
+		t.Errorf("sequences = %v, want %v", got, want)
+	}
+}
+
+func TestHubPublishesRequestID(t *testing.T) {
+	bp := backplane.NewInProcessBackplane()
+	h := newReplica(t, bp, store.NewMemoryStore())
+
+	var published []*backplane.Message
+	if err := bp.Subscribe(func(msg *backplane.Message) { published = append(published, msg) }); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	c.Request.Header.Set(middleware.RequestIDHeader, "req-1")
+	middleware.RequestID()(c)
+
+	if err := h.BroadcastToUser(c.Request.Context(), "alice", &models.Notification{ID: uuid.NewString()}); err != nil {
+		t.Fatalf("BroadcastToUser: %v", err)
+	}
+
+	if len(published) != 1 || published[0].RequestID != "req-1" {
+		t.Fatalf("published %+v, want one message with request ID req-1", published)
+	}
+}
+
+func TestHubDeliversLiveNotifications(t *testing.T) {
+	h := newTestHub(t)
+
+	alice := connect(t, h, "alice", replayCursor{})
+	bob := connect(t, h, "bob", replayCursor{})
+
+	sendToUser(t, h, "alice")
+	sendToUser(t, h, "alice")
+
+	if got := received(t, alice); !slices.Equal(got, []uint64{1, 2}) {
+		t.Errorf("alice received %v, want [1 2]", got)
+	}
+	if got := received(t, bob); len(got) != 0 {
+		t.Errorf("bob received %v, want nothing", got)
+	}
+}
+
+func TestHubReplaysQueuedNotifications(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor replayCursor
+		want   []uint64
+	}{
+		{name: "no cursor", cursor: replayCursor{}, want: nil},
+		{name: "seq cursor at zero", cursor: replayCursor{lastSeq: 0, hasSeq: true}, want: []uint64{1, 2, 3}},
+		{name: "seq cursor", cursor: replayCursor{lastSeq: 1, hasSeq: true}, want: []uint64{2, 3}},
+		{name: "up to date", cursor: replayCursor{lastSeq: 3, hasSeq: true}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHub(t)
+			for range 3 {
+				sendToUser(t, h, "alice")
+			}
+
+			client := connect(t, h, "alice", tt.cursor)
+			if got := received(t, client); !slices.Equal(got, tt.want) {
+				t.Errorf("replayed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// A user moving between replicas must not get notifications replayed that
+// reached them on the other replica.
+func TestHubReplayAcrossReplicas(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor replayCursor
+		want   []uint64
+	}{
+		{name: "no cursor", cursor: replayCursor{}, want: nil},
+		{name: "cursor at the last seen", cursor: replayCursor{lastSeq: 1, hasSeq: true}, want: []uint64{2}},
+		{name: "ID cursor at the last seen", cursor: replayCursor{lastID: "first"}, want: []uint64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp := backplane.NewInProcessBackplane()
+			notificationStore := store.NewMemoryStore()
+			a := newReplica(t, bp, notificationStore)
+			b := newReplica(t, bp, notificationStore)
+
+			first := connect(t, a, "alice", replayCursor{})
+			sent := sendToUser(t, a, "alice")
+			if got := received(t, first); !slices.Equal(got, []uint64{1}) {
+				t.Fatalf("received %v, want [1]", got)
+			}
+			disconnect(t, a, first)
+
+			sendToUser(t, a, "alice")
+
+			cursor := tt.cursor
+			if cursor.lastID == "first" {
+				cursor.lastID = sent.ID
+			}
+			second := connect(t, b, "alice", b.skipAcknowledged(context.Background(), "alice", cursor))
+			if got := received(t, second); !slices.Equal(got, tt.want) {
+				t.Errorf("replayed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHubSkipsAcknowledgedNotifications(t *testing.T) {
+	bp := backplane.NewInProcessBackplane()
+	notificationStore := store.NewMemoryStore()
+	a := newReplica(t, bp, notificationStore)
+	b := newReplica(t, bp, notificationStore)
+
+	var sent []*models.Notification
+	for range 3 {
+		sent = append(sent, sendToUser(t, a, "alice"))
+	}
+
+	// Acknowledged on a connection to another replica
+	if err := notificationStore.MarkAsRead(context.Background(), sent[1].ID, "alice"); err != nil {
+		t.Fatalf("MarkAsRead: %v", err)
+	}
+
+	cursor := b.skipAcknowledged(context.Background(), "alice", replayCursor{lastSeq: 0, hasSeq: true})
+	client := connect(t, b, "alice", cursor)
+	if got := received(t, client); !slices.Equal(got, []uint64{1, 3}) {
+		t.Errorf("replayed %v, want [1 3]", got)
+	}
+}
+
+func TestHubDeliversTopicNotifications(t *testing.T) {
+	h := newTestHub(t)
+
+	alice := connect(t, h, "alice", replayCursor{})
+	bob := connect(t, h, "bob", replayCursor{})
+	if !h.subscribe(alice, "contract:c1") {
+		t.Fatal("subscribe failed")
+	}
+
+	broadcast := func() {
+		t.Helper()
+		notification := &models.Notification{ID: "t1", Type: "test", Title: "Test", Timestamp: time.Now()}
+		if err := h.BroadcastToTopic(context.Background(), "contract:c1", notification); err != nil {
+			t.Fatalf("BroadcastToTopic: %v", err)
+		}
+	}
+
+	broadcast()
+	if got := received(t, alice); len(got) != 1 {
+		t.Errorf("alice received %d notifications, want 1", len(got))
+	}
+	if got := received(t, bob); len(got) != 0 {
+		t.Errorf("bob received %d notifications, want none", len(got))
+	}
+
+	h.unsubscribe(alice, "contract:c1")
+	broadcast()
+	if got := received(t, alice); len(got) != 0 {
+		t.Errorf("alice received %d notifications after unsubscribing, want none", len(got))
+	}
+	if n := h.GetTopicSubscriberCount("contract:c1"); n != 0 {
+		t.Errorf("topic has %d subscribers, want 0", n)
+	}
+}
+
+func TestNewHubRequiresStore(t *testing.T) {
+	if _, err := NewHub(HubConfig{}); err == nil {
+		t.Error("NewHub without a store succeeded")
+	}
+}
@@ -0,0 +1,239 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+
+	"notification-service/internal/models"
+	"notification-service/internal/store"
+	"notification-service/internal/topics"
+)
+
+// Message types exchanged on the socket.
+const (
+	// Client to server
+	MessageTypeAuth        = "auth"
+	MessageTypeSubscribe   = "subscribe"
+	MessageTypeUnsubscribe = "unsubscribe"
+	MessageTypeAck         = "ack"
+
+	// Server to client
+	MessageTypeNotification = "notification"
+	MessageTypeSubscribed   = "subscribed"
+	MessageTypeUnsubscribed = "unsubscribed"
+	MessageTypeAcked        = "acked"
+	MessageTypeError        = "error"
+)
+
+// Error codes sent in error frames.
+const (
+	ErrorCodeInvalidMessage = "INVALID_MESSAGE"
+	ErrorCodeUnknownType    = "UNKNOWN_TYPE"
+	ErrorCodeInvalidTopic   = "INVALID_TOPIC"
+	ErrorCodeTooManyTopics  = "TOO_MANY_TOPICS"
+	ErrorCodeBusy           = "BUSY"
+	ErrorCodeForbidden      = "FORBIDDEN"
+	ErrorCodeNotFound       = "NOT_FOUND"
+	ErrorCodeInternal       = "INTERNAL_ERROR"
+)
+
+const (
+	// Maximum size of a frame sent by the client. Client envelopes carry a
+	// type, a requestId, a topic of up to 66 characters or a notificationId,
+	// so 4 KiB leaves ample room for client-chosen request IDs while larger
+	// frames are rejected by closing the connection.
+	maxMessageSize = 4096
+
+	// Maximum number of topics a single connection may subscribe to.
+	maxTopicsPerClient = 50
+
+	// Maximum number of subscribe, unsubscribe and ack frames of a
+	// connection waiting to be processed.
+	maxPendingRequests = 16
+
+	// Time allowed to mark an acknowledged notification as read.
+	ackTimeout = 5 * time.Second
+)
+
+// Topics look like "contract:<id>" or "dispute:<id>".
+var topicPattern = regexp.MustCompile(`^[a-z]+:[A-Za-z0-9_-]{1,64}$`)
+
+// ValidTopic reports whether topic is a well-formed "kind:id" topic name.
+func ValidTopic(topic string) bool {
+	return topicPattern.MatchString(topic)
+}
+
+// Envelope is the JSON frame exchanged in both directions on the socket.
+// RequestID is chosen by the client and echoed on the matching reply.
+type Envelope struct {
+	Type           string               `json:"type"`
+	RequestID      string               `json:"requestId,omitempty"`
+	Topic          string               `json:"topic,omitempty"`
+	NotificationID string               `json:"notificationId,omitempty"`
+	Data           *models.Notification `json:"data,omitempty"`
+	Error          *EnvelopeError       `json:"error,omitempty"`
+}
+
+type EnvelopeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func notificationFrame(notification *models.Notification) ([]byte, error) {
+	return json.Marshal(&Envelope{
+		Type: MessageTypeNotification,
+		Data: notification,
+	})
+}
+
+// handleMessage dispatches a frame received from the client.
+func (c *Client) handleMessage(data []byte) {
+	var msg Envelope
+	if err := json.Unmarshal(data, &msg); err != nil {
+		c.sendError("", ErrorCodeInvalidMessage, "Message must be a JSON object")
+		return
+	}
+
+	switch msg.Type {
+	case MessageTypeSubscribe, MessageTypeUnsubscribe, MessageTypeAck:
+		select {
+		case c.requests <- &msg:
+		default:
+			c.sendError(msg.RequestID, ErrorCodeBusy, "Too many requests in progress")
+		}
+	default:
+		c.sendError(msg.RequestID, ErrorCodeUnknownType, "Unknown message type")
+	}
+}
+
+// processRequests handles the queued requests of the client one at a time,
+// in the order they were received, until the connection closes.
+func (c *Client) processRequests() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case msg := <-c.requests:
+			switch msg.Type {
+			case MessageTypeSubscribe:
+				c.handleSubscribe(msg)
+			case MessageTypeUnsubscribe:
+				c.handleUnsubscribe(msg)
+			case MessageTypeAck:
+				c.handleAck(msg)
+			}
+		}
+	}
+}
+
+func (c *Client) handleSubscribe(msg *Envelope) {
+	if !ValidTopic(msg.Topic) {
+		c.sendError(msg.RequestID, ErrorCodeInvalidTopic, "Topic must look like kind:id")
+		return
+	}
+
+	err := c.hub.authorizeTopic(c.ctx, c.claims, msg.Topic)
+	if c.ctx.Err() != nil {
+		return
+	}
+	if errors.Is(err, topics.ErrForbidden) {
+		c.logger.Warn("Topic subscription denied",
+			zap.String("topic", msg.Topic),
+			zap.String("userID", c.userID),
+		)
+		c.sendError(msg.RequestID, ErrorCodeForbidden, "Not allowed to subscribe to this topic")
+		return
+	}
+	if err != nil {
+		c.logger.Error("Failed to check topic membership",
+			zap.Error(err),
+			zap.String("topic", msg.Topic),
+			zap.String("userID", c.userID),
+		)
+		c.sendError(msg.RequestID, ErrorCodeInternal, "Failed to check topic membership")
+		return
+	}
+
+	if !c.hub.subscribe(c, msg.Topic) {
+		c.sendError(msg.RequestID, ErrorCodeTooManyTopics, "Too many topic subscriptions")
+		return
+	}
+
+	c.reply(&Envelope{
+		Type:      MessageTypeSubscribed,
+		RequestID: msg.RequestID,
+		Topic:     msg.Topic,
+	})
+}
+
+func (c *Client) handleUnsubscribe(msg *Envelope) {
+	if msg.Topic == "" {
+		c.sendError(msg.RequestID, ErrorCodeInvalidTopic, "Topic is required")
+		return
+	}
+
+	c.hub.unsubscribe(c, msg.Topic)
+
+	c.reply(&Envelope{
+		Type:      MessageTypeUnsubscribed,
+		RequestID: msg.RequestID,
+		Topic:     msg.Topic,
+	})
+}
+
+func (c *Client) handleAck(msg *Envelope) {
+	if msg.NotificationID == "" {
+		c.sendError(msg.RequestID, ErrorCodeInvalidMessage, "Notification ID is required")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, ackTimeout)
+	defer cancel()
+
+	err := c.hub.store.MarkAsRead(ctx, msg.NotificationID, c.userID)
+	if errors.Is(err, store.ErrNotFound) {
+		c.sendError(msg.RequestID, ErrorCodeNotFound, "Notification not found")
+		return
+	}
+	if err != nil {
+		c.logger.Error("Failed to mark notification as read",
+			zap.Error(err),
+			zap.String("notificationID", msg.NotificationID),
+			zap.String("userID", c.userID),
+		)
+		c.sendError(msg.RequestID, ErrorCodeInternal, "Failed to acknowledge notification")
+		return
+	}
+
+	c.reply(&Envelope{
+		Type:           MessageTypeAcked,
+		RequestID:      msg.RequestID,
+		NotificationID: msg.NotificationID,
+	})
+}
+
+func (c *Client) sendError(requestID, code, message string) {
+	c.reply(&Envelope{
+		Type:      MessageTypeError,
+		RequestID: requestID,
+		Error: &EnvelopeError{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
+// reply queues a frame for this client only.
+func (c *Client) reply(msg *Envelope) {
+	message, err := json.Marshal(msg)
+	if err != nil {
+		c.logger.Error("Failed to marshal reply", zap.Error(err))
+		return
+	}
+	c.hub.sendTo(c, message)
+}
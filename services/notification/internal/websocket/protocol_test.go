@@ -0,0 +1,283 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
+
+	"notification-service/internal/auth"
+	"notification-service/internal/backplane"
+	"notification-service/internal/models"
+	"notification-service/internal/store"
+	"notification-service/internal/topics"
+	"notification-service/shared/jwtauth"
+)
+
+const testSecret = "test-secret"
+
+func testToken(t *testing.T, userID string) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": userID,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return token
+}
+
+// dialTestHub serves a hub over HTTP and opens a socket to it as userID.
+func dialTestHub(t *testing.T, config HubConfig, userID string) *websocket.Conn {
+	t.Helper()
+
+	if config.Validator == nil {
+		validator, err := auth.NewTokenValidator(auth.ValidatorConfig{Config: jwtauth.Config{HMACSecret: testSecret}})
+		if err != nil {
+			t.Fatalf("NewTokenValidator: %v", err)
+		}
+		config.Validator = validator
+	}
+	if config.Backplane == nil {
+		config.Backplane = backplane.NewInProcessBackplane()
+	}
+	if config.Store == nil {
+		config.Store = store.NewMemoryStore()
+	}
+	h, err := NewHub(config)
+	if err != nil {
+		t.Fatalf("NewHub: %v", err)
+	}
+	go h.Run()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", h.ServeWS)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+testToken(t, userID))
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", header)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// memberTopics authorizes the listed topics and forbids every other one.
+type memberTopics map[string]bool
+
+func (m memberTopics) Authorize(_ context.Context, _ *auth.Claims, topic string) error {
+	if topic == "dispute:down" {
+		return errors.New("dispute service unavailable")
+	}
+	if !m[topic] {
+		return topics.ErrForbidden
+	}
+	return nil
+}
+
+func readEnvelope(t *testing.T, conn *websocket.Conn) Envelope {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var frame Envelope
+	if err := conn.ReadJSON(&frame); err != nil {
+		t.Fatalf("ReadJSON: %v", err)
+	}
+	return frame
+}
+
+func TestFrameSizeLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		wantCode  string
+		wantClose bool
+	}{
+		{name: "small frame", requestID: "1", wantCode: ErrorCodeNotFound},
+		{name: "frame above the former 512 byte limit", requestID: strings.Repeat("r", 1024), wantCode: ErrorCodeNotFound},
+		{name: "frame above the limit", requestID: strings.Repeat("r", maxMessageSize), wantClose: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dialTestHub(t, HubConfig{}, "alice")
+
+			err := conn.WriteJSON(&Envelope{
+				Type:           MessageTypeAck,
+				RequestID:      tt.requestID,
+				NotificationID: "missing",
+			})
+			if err != nil {
+				t.Fatalf("WriteJSON: %v", err)
+			}
+
+			if tt.wantClose {
+				conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+				var frame Envelope
+				if err := conn.ReadJSON(&frame); !websocket.IsCloseError(err, websocket.CloseMessageTooBig) {
+					t.Fatalf("ReadJSON = %+v, %v, want close %d", frame, err, websocket.CloseMessageTooBig)
+				}
+				return
+			}
+
+			frame := readEnvelope(t, conn)
+			if frame.Type != MessageTypeError || frame.Error == nil || frame.Error.Code != tt.wantCode {
+				t.Fatalf("reply = %+v, want %s error", frame, tt.wantCode)
+			}
+			if frame.RequestID != tt.requestID {
+				t.Errorf("requestId was not echoed")
+			}
+		})
+	}
+}
+
+func TestSubscribeChecksMembership(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   HubConfig
+		topic    string
+		wantType string
+		wantCode string
+	}{
+		{
+			name:     "member",
+			config:   HubConfig{Topics: memberTopics{"contract:c1": true}},
+			topic:    "contract:c1",
+			wantType: MessageTypeSubscribed,
+		},
+		{
+			name:     "not a member",
+			config:   HubConfig{Topics: memberTopics{"contract:c1": true}},
+			topic:    "contract:c2",
+			wantType: MessageTypeError,
+			wantCode: ErrorCodeForbidden,
+		},
+		{
+			name:     "membership lookup fails",
+			config:   HubConfig{Topics: memberTopics{}},
+			topic:    "dispute:down",
+			wantType: MessageTypeError,
+			wantCode: ErrorCodeInternal,
+		},
+		{
+			name:     "no authorizer",
+			config:   HubConfig{},
+			topic:    "contract:c1",
+			wantType: MessageTypeError,
+			wantCode: ErrorCodeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dialTestHub(t, tt.config, "alice")
+
+			err := conn.WriteJSON(&Envelope{Type: MessageTypeSubscribe, RequestID: "1", Topic: tt.topic})
+			if err != nil {
+				t.Fatalf("WriteJSON: %v", err)
+			}
+
+			frame := readEnvelope(t, conn)
+			if frame.Type != tt.wantType {
+				t.Fatalf("reply = %+v, want %s", frame, tt.wantType)
+			}
+			if tt.wantCode != "" && (frame.Error == nil || frame.Error.Code != tt.wantCode) {
+				t.Errorf("reply = %+v, want %s error", frame, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAck(t *testing.T) {
+	tests := []struct {
+		name           string
+		notificationID string
+		wantType       string
+		wantCode       string
+	}{
+		{name: "own notification", notificationID: "mine", wantType: MessageTypeAcked},
+		{name: "topic notification", notificationID: "topic", wantType: MessageTypeAcked},
+		{name: "broadcast notification", notificationID: "everyone", wantType: MessageTypeAcked},
+		{name: "other user's notification", notificationID: "theirs", wantType: MessageTypeError, wantCode: ErrorCodeNotFound},
+		{name: "unknown notification", notificationID: "missing", wantType: MessageTypeError, wantCode: ErrorCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationStore := store.NewMemoryStore()
+			for _, n := range []*models.Notification{
+				{ID: "mine", UserID: "alice"},
+				{ID: "theirs", UserID: "bob"},
+				{ID: "topic", Topic: "contract:c1"},
+				{ID: "everyone"},
+			} {
+				if err := notificationStore.Save(context.Background(), n); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			}
+			conn := dialTestHub(t, HubConfig{Store: notificationStore}, "alice")
+
+			err := conn.WriteJSON(&Envelope{Type: MessageTypeAck, RequestID: "1", NotificationID: tt.notificationID})
+			if err != nil {
+				t.Fatalf("WriteJSON: %v", err)
+			}
+
+			frame := readEnvelope(t, conn)
+			if frame.Type != tt.wantType {
+				t.Fatalf("reply = %+v, want %s", frame, tt.wantType)
+			}
+			if tt.wantCode != "" && (frame.Error == nil || frame.Error.Code != tt.wantCode) {
+				t.Errorf("reply = %+v, want %s error", frame, tt.wantCode)
+			}
+		})
+	}
+}
+
+// stalledTopics blocks every membership lookup until its context is done.
+type stalledTopics struct {
+	canceled chan struct{}
+}
+
+func (s *stalledTopics) Authorize(ctx context.Context, _ *auth.Claims, _ string) error {
+	<-ctx.Done()
+	close(s.canceled)
+	return ctx.Err()
+}
+
+func TestSubscribeDoesNotBlockReads(t *testing.T) {
+	authorizer := &stalledTopics{canceled: make(chan struct{})}
+	conn := dialTestHub(t, HubConfig{Topics: authorizer}, "alice")
+
+	if err := conn.WriteJSON(&Envelope{Type: MessageTypeSubscribe, RequestID: "1", Topic: "contract:c1"}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if err := conn.WriteJSON(&Envelope{Type: "ping", RequestID: "2"}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	// The unknown type is answered while the subscription is still pending
+	frame := readEnvelope(t, conn)
+	if frame.RequestID != "2" || frame.Error == nil || frame.Error.Code != ErrorCodeUnknownType {
+		t.Fatalf("reply = %+v, want %s error for request 2", frame, ErrorCodeUnknownType)
+	}
+
+	conn.Close()
+	select {
+	case <-authorizer.canceled:
+	case <-time.After(2 * time.Second):
+		t.Error("membership lookup was not canceled when the connection closed")
+	}
+}
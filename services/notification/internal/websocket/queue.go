@@ -0,0 +1,116 @@
+package websocket
+
+import (
+	"time"
+)
+
+const (
+	// Maximum number of notifications kept per user for replay.
+	maxQueuedPerUser = 100
+
+	// How long a notification stays available for replay.
+	queueTTL = 24 * time.Hour
+
+	// How often expired notifications are pruned from the queues.
+	queuePruneInterval = time.Minute
+)
+
+type queuedMessage struct {
+	seq      uint64
+	id       string
+	message  []byte
+	queuedAt time.Time
+}
+
+// replayCursor is the last notification a reconnecting client has seen,
+// identified either by its sequence number or by its notification ID.
+// Notifications listed in acked were acknowledged by the user, possibly on
+// another connection, and are not replayed.
+type replayCursor struct {
+	lastSeq uint64
+	hasSeq  bool
+	lastID  string
+	acked   map[string]bool
+}
+
+func (c replayCursor) isSet() bool {
+	return c.hasSeq || c.lastID != ""
+}
+
+// userQueue holds the most recent notifications sent to a user, in sequence
+// order, so they can be replayed when the user reconnects.
+type userQueue struct {
+	lastSeq  uint64
+	messages []*queuedMessage
+}
+
+// push adds a message keeping the queue ordered by sequence number, since
+// messages published by different replicas may arrive slightly out of order.
+func (q *userQueue) push(m *queuedMessage) {
+	i := len(q.messages)
+	for i > 0 && q.messages[i-1].seq > m.seq {
+		i--
+	}
+	q.messages = append(q.messages, nil)
+	copy(q.messages[i+1:], q.messages[i:])
+	q.messages[i] = m
+
+	if m.seq > q.lastSeq {
+		q.lastSeq = m.seq
+	}
+
+	if len(q.messages) > maxQueuedPerUser {
+		q.messages = q.messages[len(q.messages)-maxQueuedPerUser:]
+	}
+}
+
+func (q *userQueue) prune(now time.Time) {
+	i := 0
+	for i < len(q.messages) && now.Sub(q.messages[i].queuedAt) > queueTTL {
+		i++
+	}
+	q.messages = q.messages[i:]
+}
+
+// pending returns the messages a client reconnecting with the given cursor
+// has not seen yet, leaving out acknowledged ones. Every replica queues every
+// message, and whether one reached the user elsewhere is only known to the
+// client, so nothing is replayed without a cursor.
+func (q *userQueue) pending(cursor replayCursor) []*queuedMessage {
+	var pending []*queuedMessage
+	for _, m := range q.after(cursor) {
+		if !cursor.acked[m.id] {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
+
+// after returns the messages queued after the cursor.
+func (q *userQueue) after(cursor replayCursor) []*queuedMessage {
+	switch {
+	case cursor.lastID != "":
+		for i, m := range q.messages {
+			if m.id == cursor.lastID {
+				return q.messages[i+1:]
+			}
+		}
+		// The ID has already been evicted, so everything queued is newer
+		return q.messages
+
+	case cursor.hasSeq:
+		if cursor.lastSeq > q.lastSeq {
+			// The sequence was reset, e.g. after a restart
+			return q.messages
+		}
+		for i, m := range q.messages {
+			if m.seq > cursor.lastSeq {
+				return q.messages[i:]
+			}
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+package websocket
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// queueOf builds a queue holding the given sequence numbers, pushed in that
+// order. Notification IDs are "n<seq>".
+func queueOf(seqs ...uint64) *userQueue {
+	q := &userQueue{}
+	for _, seq := range seqs {
+		q.push(&queuedMessage{
+			seq:      seq,
+			id:       "n" + strconv.FormatUint(seq, 10),
+			queuedAt: time.Now(),
+		})
+	}
+	return q
+}
+
+func seqsOf(messages []*queuedMessage) []uint64 {
+	seqs := make([]uint64, 0, len(messages))
+	for _, m := range messages {
+		seqs = append(seqs, m.seq)
+	}
+	return seqs
+}
+
+func TestUserQueuePush(t *testing.T) {
+	tests := []struct {
+		name        string
+		pushed      []uint64
+		wantSeqs    []uint64
+		wantLastSeq uint64
+	}{
+		{
+			name:        "in order",
+			pushed:      []uint64{1, 2, 3},
+			wantSeqs:    []uint64{1, 2, 3},
+			wantLastSeq: 3,
+		},
+		{
+			name:        "out of order",
+			pushed:      []uint64{1, 3, 2, 5, 4},
+			wantSeqs:    []uint64{1, 2, 3, 4, 5},
+			wantLastSeq: 5,
+		},
+		{
+			name:        "late arrival before everything",
+			pushed:      []uint64{2, 3, 1},
+			wantSeqs:    []uint64{1, 2, 3},
+			wantLastSeq: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := queueOf(tt.pushed...)
+			if got := seqsOf(q.messages); !slices.Equal(got, tt.wantSeqs) {
+				t.Errorf("queued = %v, want %v", got, tt.wantSeqs)
+			}
+			if q.lastSeq != tt.wantLastSeq {
+				t.Errorf("lastSeq = %d, want %d", q.lastSeq, tt.wantLastSeq)
+			}
+		})
+	}
+}
+
+func TestUserQueuePushEvictsOldest(t *testing.T) {
+	q := &userQueue{}
+	for seq := uint64(1); seq <= maxQueuedPerUser+10; seq++ {
+		q.push(&queuedMessage{seq: seq, queuedAt: time.Now()})
+	}
+
+	if len(q.messages) != maxQueuedPerUser {
+		t.Fatalf("queued %d messages, want %d", len(q.messages), maxQueuedPerUser)
+	}
+	if first := q.messages[0].seq; first != 11 {
+		t.Errorf("oldest queued seq = %d, want 11", first)
+	}
+}
+
+func TestUserQueuePrune(t *testing.T) {
+	now := time.Now()
+	q := &userQueue{}
+	q.push(&queuedMessage{seq: 1, queuedAt: now.Add(-queueTTL - time.Minute)})
+	q.push(&queuedMessage{seq: 2, queuedAt: now.Add(-queueTTL - time.Second)})
+	q.push(&queuedMessage{seq: 3, queuedAt: now.Add(-time.Hour)})
+
+	q.prune(now)
+
+	if got := seqsOf(q.messages); !slices.Equal(got, []uint64{3}) {
+		t.Errorf("after prune = %v, want [3]", got)
+	}
+}
+
+func TestUserQueuePending(t *testing.T) {
+	tests := []struct {
+		name   string
+		queue  *userQueue
+		cursor replayCursor
+		want   []uint64
+	}{
+		{
+			name:   "no cursor replays nothing",
+			queue:  queueOf(1, 2, 3, 4, 5),
+			cursor: replayCursor{},
+			want:   nil,
+		},
+		{
+			name:   "seq cursor",
+			queue:  queueOf(1, 2, 3, 4, 5),
+			cursor: replayCursor{lastSeq: 3, hasSeq: true},
+			want:   []uint64{4, 5},
+		},
+		{
+			name:   "seq cursor at zero replays everything",
+			queue:  queueOf(1, 2, 3),
+			cursor: replayCursor{lastSeq: 0, hasSeq: true},
+			want:   []uint64{1, 2, 3},
+		},
+		{
+			name:   "seq cursor up to date",
+			queue:  queueOf(1, 2, 3),
+			cursor: replayCursor{lastSeq: 3, hasSeq: true},
+			want:   nil,
+		},
+		{
+			name:   "seq cursor ahead of the queue after a reset",
+			queue:  queueOf(1, 2),
+			cursor: replayCursor{lastSeq: 40, hasSeq: true},
+			want:   []uint64{1, 2},
+		},
+		{
+			name:   "seq cursor older than the queue",
+			queue:  queueOf(7, 8, 9),
+			cursor: replayCursor{lastSeq: 2, hasSeq: true},
+			want:   []uint64{7, 8, 9},
+		},
+		{
+			name:   "ID cursor",
+			queue:  queueOf(1, 2, 3, 4),
+			cursor: replayCursor{lastID: "n2"},
+			want:   []uint64{3, 4},
+		},
+		{
+			name:   "evicted ID cursor replays everything",
+			queue:  queueOf(5, 6),
+			cursor: replayCursor{lastID: "n1"},
+			want:   []uint64{5, 6},
+		},
+		{
+			name:   "ID cursor wins over seq cursor",
+			queue:  queueOf(1, 2, 3, 4),
+			cursor: replayCursor{lastID: "n3", lastSeq: 1, hasSeq: true},
+			want:   []uint64{4},
+		},
+		{
+			name:   "acknowledged messages are skipped",
+			queue:  queueOf(1, 2, 3, 4),
+			cursor: replayCursor{lastSeq: 1, hasSeq: true, acked: map[string]bool{"n1": true, "n3": true}},
+			want:   []uint64{2, 4},
+		},
+		{
+			name:   "acknowledged messages without a cursor",
+			queue:  queueOf(1, 2),
+			cursor: replayCursor{acked: map[string]bool{"n1": true}},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := seqsOf(tt.queue.pending(tt.cursor)); !slices.Equal(got, tt.want) {
+				t.Errorf("pending = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"notification-service/internal/topics"
+	"notification-service/shared/jwtauth"
+	"notification-service/shared/middleware"
+	"notification-service/shared/response"
+)
+
+// Send a comment line with this period so proxies do not close an idle
+// stream.
+const sseHeartbeatPeriod = 25 * time.Second
+
+// ServeSSE streams the user's notifications as Server-Sent Events, for
+// clients that cannot open a WebSocket. The stream is fed by the same hub as
+// ServeWS: user notifications carry their sequence number as the event ID so
+// a reconnecting client resumes from the Last-Event-ID header, and topics can
+// be subscribed with a comma-separated topics query parameter.
+func (h *Hub) ServeSSE(c *gin.Context) {
+	logger := middleware.RequestLogger(c)
+
+	token, ok := jwtauth.BearerToken(c.GetHeader("Authorization"))
+	if !ok {
+		response.Unauthorized(c, "Authorization header is required")
+		return
+	}
+
+	claims, err := h.validator.Validate(c.Request.Context(), token)
+	if err != nil {
+		logger.Warn("SSE authentication failed", zap.Error(err))
+		response.Unauthorized(c, "Invalid or expired token")
+		return
+	}
+
+	topicList, err := parseTopics(c.Query("topics"))
+	if err != nil {
+		response.BadRequest(c, "Invalid topics: "+err.Error())
+		return
+	}
+
+	for _, topic := range topicList {
+		err := h.authorizeTopic(c.Request.Context(), claims, topic)
+		if errors.Is(err, topics.ErrForbidden) {
+			logger.Warn("Topic subscription denied",
+				zap.String("topic", topic),
+				zap.String("userID", claims.UserID),
+			)
+			response.Forbidden(c, "Not allowed to subscribe to "+topic)
+			return
+		}
+		if err != nil {
+			logger.Error("Failed to check topic membership", zap.Error(err), zap.String("topic", topic))
+			response.InternalError(c, "Failed to check topic membership")
+			return
+		}
+	}
+
+	// The stream outlives the server's write timeout
+	controller := http.NewResponseController(c.Writer)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Warn("Failed to clear write deadline", zap.Error(err))
+	}
+
+	clientID := c.Query("clientId")
+	if clientID == "" {
+		clientID = "anonymous"
+	}
+
+	replay := parseReplayCursor(c)
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		if seq, err := strconv.ParseUint(lastEventID, 10, 64); err == nil {
+			replay = replayCursor{lastSeq: seq, hasSeq: true}
+		}
+	}
+
+	client := &Client{
+		hub:      h,
+		send:     make(chan []byte, 256),
+		userID:   claims.UserID,
+		claims:   claims,
+		clientID: clientID,
+		logger:   logger,
+		replay:   h.skipAcknowledged(c.Request.Context(), claims.UserID, replay),
+		topics:   make(map[string]bool),
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	h.register <- client
+	defer func() {
+		h.unregister <- client
+	}()
+
+	for _, topic := range topicList {
+		h.subscribe(client, topic)
+	}
+
+	ticker := time.NewTicker(sseHeartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case message, ok := <-client.send:
+			if !ok {
+				return
+			}
+			if err := writeEvent(c.Writer, message); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEvent writes an envelope as an SSE event named after its type. User
+// notifications carry their sequence number as the event ID.
+func writeEvent(w http.ResponseWriter, message []byte) error {
+	var frame Envelope
+	if err := json.Unmarshal(message, &frame); err != nil {
+		return err
+	}
+
+	var event strings.Builder
+	if frame.Data != nil && frame.Data.Seq > 0 {
+		fmt.Fprintf(&event, "id: %d\n", frame.Data.Seq)
+	}
+	fmt.Fprintf(&event, "event: %s\n", frame.Type)
+	fmt.Fprintf(&event, "data: %s\n\n", message)
+
+	_, err := w.Write([]byte(event.String()))
+	return err
+}
+
+// parseTopics splits a comma-separated topic list.
+func parseTopics(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var topics []string
+	for _, topic := range strings.Split(value, ",") {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+		if !ValidTopic(topic) {
+			return nil, fmt.Errorf("%q does not look like kind:id", topic)
+		}
+		topics = append(topics, topic)
+	}
+
+	if len(topics) > maxTopicsPerClient {
+		return nil, fmt.Errorf("at most %d topics are allowed", maxTopicsPerClient)
+	}
+
+	return topics, nil
+}
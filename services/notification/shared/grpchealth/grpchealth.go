@@ -0,0 +1,80 @@
+// Package grpchealth serves the standard grpc.health.v1 service, which the
+// API gateway uses to take unhealthy backends out of rotation. A service
+// reports NOT_SERVING while a dependency it cannot work without, such as its
+// database, is down.
+package grpchealth
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// Time between two dependency checks.
+	checkInterval = 10 * time.Second
+
+	// Time allowed for one dependency check.
+	checkTimeout = 2 * time.Second
+)
+
+// Check returns an error while a dependency of the service is unavailable.
+type Check func(ctx context.Context) error
+
+// Register adds the health service to server and keeps the status of the
+// server and of every service registered on it in step with check until ctx
+// is done. Register it after the other services. Call Shutdown on the
+// returned server before stopping the gRPC server so clients move away while
+// in-flight calls finish.
+func Register(ctx context.Context, server *grpc.Server, check Check) *health.Server {
+	healthServer := health.NewServer()
+
+	services := []string{""}
+	for name := range server.GetServiceInfo() {
+		services = append(services, name)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		serving := true
+		for {
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			err := check(checkCtx)
+			cancel()
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil && serving {
+				zap.L().Error("Dependency check failed, reporting NOT_SERVING", zap.Error(err))
+			} else if err == nil && !serving {
+				zap.L().Info("Dependency check passed, reporting SERVING")
+			}
+			serving = err == nil
+
+			status := healthpb.HealthCheckResponse_SERVING
+			if !serving {
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+			for _, service := range services {
+				healthServer.SetServingStatus(service, status)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return healthServer
+}
@@ -0,0 +1,121 @@
+// Package grpcmeta carries the caller of a request from the API gateway to
+// the backend services as gRPC metadata. The deadline travels with the call
+// itself, so services see the time the gateway has left.
+package grpcmeta
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys set by the gateway.
+const (
+	RequestIDKey = "x-request-id"
+	UserIDKey    = "x-user-id"
+	EmailKey     = "x-user-email"
+	RolesKey     = "x-user-roles"
+)
+
+// Caller identifies the request a call is made for and the authenticated
+// user, if any. Services trust it because only the gateway calls them.
+type Caller struct {
+	RequestID string
+	UserID    string
+	Email     string
+	Roles     []string
+}
+
+type callerKey struct{}
+
+type loggerKey struct{}
+
+// NewOutgoingContext attaches the caller to the metadata of calls made with
+// ctx.
+func NewOutgoingContext(ctx context.Context, caller Caller) context.Context {
+	var pairs []string
+	if caller.RequestID != "" {
+		pairs = append(pairs, RequestIDKey, caller.RequestID)
+	}
+	if caller.UserID != "" {
+		pairs = append(pairs, UserIDKey, caller.UserID)
+	}
+	if caller.Email != "" {
+		pairs = append(pairs, EmailKey, caller.Email)
+	}
+	for _, role := range caller.Roles {
+		pairs = append(pairs, RolesKey, role)
+	}
+
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// FromIncomingContext reads the caller from the metadata of a received call.
+func FromIncomingContext(ctx context.Context) Caller {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return Caller{
+		RequestID: first(md, RequestIDKey),
+		UserID:    first(md, UserIDKey),
+		Email:     first(md, EmailKey),
+		Roles:     md.Get(RolesKey),
+	}
+}
+
+// FromContext returns the caller stored by UnaryServerInterceptor.
+func FromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
+
+// Logger returns the logger of the call, which adds the request ID and the
+// user ID to every line, or the global logger outside of a call.
+func Logger(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
+
+// UnaryServerInterceptor makes the caller available through FromContext and
+// Logger, and logs every call with it.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		caller := FromIncomingContext(ctx)
+		logger := zap.L().With(
+			zap.String("request_id", caller.RequestID),
+			zap.String("user_id", caller.UserID),
+		)
+		ctx = context.WithValue(ctx, callerKey{}, caller)
+		ctx = context.WithValue(ctx, loggerKey{}, logger)
+
+		resp, err := handler(ctx, req)
+
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("latency", time.Since(start)),
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			fields = append(fields, zap.Duration("deadline_left", time.Until(deadline)))
+		}
+		logger.Info("gRPC Request", fields...)
+
+		return resp, err
+	}
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+package jwtauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func encodeBigInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   encodeBigInt(key.N),
+		E:   encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "EC",
+		Kid: kid,
+		Crv: "P-256",
+		X:   encodeBigInt(key.X),
+		Y:   encodeBigInt(key.Y),
+	}
+}
+
+func marshalJWKS(t *testing.T, keys ...jsonWebKey) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string][]jsonWebKey{"keys": keys})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	return data
+}
+
+// writeJWKS writes a key set holding the given keys and loads it.
+func writeJWKS(t *testing.T, keys ...jsonWebKey) *JWKS {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, marshalJWKS(t, keys...), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	jwks, err := NewJWKSFromFile(path)
+	if err != nil {
+		t.Fatalf("NewJWKSFromFile: %v", err)
+	}
+	return jwks
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return signed
+}
+
+func TestParser(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	parser, err := NewParser(Config{
+		JWKS:       writeJWKS(t, rsaJWK("rsa-1", rsaKey), ecJWK("ec-1", ecKey)),
+		HMACSecret: testSecret,
+		Issuer:     "https://auth.example.com",
+	})
+	if err != nil {
+		t.Fatalf("NewParser: %v", err)
+	}
+
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":   "alice",
+			"email": "alice@example.com",
+			"roles": []string{"mediator"},
+			"iss":   "https://auth.example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(nil))},
+		{name: "RS256 with kid", token: sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(nil))},
+		{name: "RS256 without kid", token: sign(t, jwt.SigningMethodRS256, "", rsaKey, claims(nil))},
+		{name: "ES256 with kid", token: sign(t, jwt.SigningMethodES256, "ec-1", ecKey, claims(nil))},
+		{name: "refresh token", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(jwt.MapClaims{"token_use": "refresh"}))},
+		{name: "unknown signing key", token: sign(t, jwt.SigningMethodRS256, "", otherKey, claims(nil)), wantErr: true},
+		{name: "kid of another key type", token: sign(t, jwt.SigningMethodRS256, "ec-1", rsaKey, claims(nil)), wantErr: true},
+		{name: "wrong HMAC secret", token: sign(t, jwt.SigningMethodHS256, "", []byte("other"), claims(nil)), wantErr: true},
+		{name: "unsupported algorithm", token: sign(t, jwt.SigningMethodHS512, "", []byte(testSecret), claims(nil)), wantErr: true},
+		{name: "wrong issuer", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(jwt.MapClaims{"iss": "https://evil.example.com"})), wantErr: true},
+		{name: "expired", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})), wantErr: true},
+		{name: "no expiry", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(jwt.MapClaims{"exp": nil})), wantErr: true},
+		{name: "no subject", token: sign(t, jwt.SigningMethodHS256, "", []byte(testSecret), claims(jwt.MapClaims{"sub": nil})), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.Parse(tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got.UserID != "alice" || len(got.Roles) != 1 || got.Roles[0] != "mediator" {
+				t.Errorf("claims = %+v", got)
+			}
+		})
+	}
+}
+
+func TestNewParserRequiresKey(t *testing.T) {
+	if _, err := NewParser(Config{Issuer: "https://auth.example.com"}); !errors.Is(err, ErrNoKeyConfigured) {
+		t.Errorf("NewParser = %v, want %v", err, ErrNoKeyConfigured)
+	}
+}
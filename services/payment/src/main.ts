@@ -2,7 +2,9 @@ import { NestFactory } from '@nestjs/core';
 import { ValidationPipe } from '@nestjs/common';
 import { Transport, MicroserviceOptions } from '@nestjs/microservices';
 import { join } from 'path';
+import { DataSource } from 'typeorm';
 import { AppModule } from './app.module';
+import { GrpcHealth } from './shared/health/grpc-health';
 
 async function bootstrap() {
   // Create HTTP application
@@ -27,6 +29,9 @@ async function bootstrap() {
   const httpPort = process.env.PORT || 3003;
   const grpcPort = process.env.GRPC_PORT || 50053;
 
+  // gRPC health service, NOT_SERVING while the database is down
+  const grpcHealth = new GrpcHealth(['payment.PaymentService']);
+
   // Create gRPC microservice
   const grpcApp = await NestFactory.createMicroservice<MicroserviceOptions>(AppModule, {
     transport: Transport.GRPC,
@@ -34,8 +39,10 @@ async function bootstrap() {
       package: 'payment',
       protoPath: join(__dirname, '../../../proto/payment.proto'),
       url: `0.0.0.0:${grpcPort}`,
+      onLoadPackageDefinition: grpcHealth.addToServer,
     },
   });
+  grpcHealth.watch(grpcApp.get(DataSource));
 
   // Start gRPC microservice
   await grpcApp.listen();
@@ -44,6 +51,7 @@ async function bootstrap() {
   // Graceful shutdown
   process.on('SIGTERM', async () => {
     console.log('SIGTERM received, shutting down gracefully');
+    grpcHealth.shutdown();
     await app.close();
     await grpcApp.close();
     process.exit(0);
@@ -51,6 +59,7 @@ async function bootstrap() {
 
   process.on('SIGINT', async () => {
     console.log('SIGINT received, shutting down gracefully');
+    grpcHealth.shutdown();
     await app.close();
     await grpcApp.close();
     process.exit(0);
@@ -0,0 +1,28 @@
+package jwtauth
+
+import "testing"
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantToken string
+		wantOK    bool
+	}{
+		{header: "Bearer abc", wantToken: "abc", wantOK: true},
+		{header: "bearer abc", wantToken: "abc", wantOK: true},
+		{header: "BEARER abc", wantToken: "abc", wantOK: true},
+		{header: "Bearer  abc ", wantToken: "abc", wantOK: true},
+		{header: "Bearer "},
+		{header: "Bearer"},
+		{header: "Bearerabc"},
+		{header: "Basic abc"},
+		{header: ""},
+	}
+
+	for _, tt := range tests {
+		token, ok := BearerToken(tt.header)
+		if token != tt.wantToken || ok != tt.wantOK {
+			t.Errorf("BearerToken(%q) = %q, %v, want %q, %v", tt.header, token, ok, tt.wantToken, tt.wantOK)
+		}
+	}
+}
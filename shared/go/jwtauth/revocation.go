@@ -0,0 +1,121 @@
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// RevocationList records tokens that must be rejected before they expire,
+// either individually by jti or all tokens of a user issued before a time.
+type RevocationList interface {
+	// RevokeToken rejects the token with the given jti until expiresAt.
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// ClaimToken revokes the token with the given jti until expiresAt and
+	// reports whether this call revoked it. Of concurrent calls for the same
+	// jti exactly one returns true, so single-use tokens can be redeemed
+	// atomically.
+	ClaimToken(ctx context.Context, jti string, expiresAt time.Time) (bool, error)
+
+	// RevokeUserTokens rejects every token of the user issued before the
+	// second of the given time. Token issue times only have second
+	// precision, so tokens issued during that second stay valid, which lets
+	// the user log in again right away. Of several cutoffs the latest wins.
+	RevokeUserTokens(ctx context.Context, userID string, before time.Time) error
+
+	// IsRevoked reports whether the token described by claims was revoked.
+	IsRevoked(ctx context.Context, claims *Claims) (bool, error)
+
+	// Close releases the connections of the list.
+	Close() error
+}
+
+// MemoryRevocationList keeps revocations in process memory. It suits tests
+// and single-instance deployments.
+type MemoryRevocationList struct {
+	mutex      sync.Mutex
+	tokens     map[string]time.Time
+	userCutoff map[string]time.Time
+}
+
+func NewMemoryRevocationList() *MemoryRevocationList {
+	return &MemoryRevocationList{
+		tokens:     make(map[string]time.Time),
+		userCutoff: make(map[string]time.Time),
+	}
+}
+
+func (l *MemoryRevocationList) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.pruneTokens(time.Now())
+	l.tokens[jti] = expiresAt
+	return nil
+}
+
+func (l *MemoryRevocationList) ClaimToken(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.pruneTokens(time.Now())
+	if _, ok := l.tokens[jti]; ok {
+		return false, nil
+	}
+	l.tokens[jti] = expiresAt
+	return true, nil
+}
+
+// pruneTokens drops entries for tokens that have expired anyway. The caller
+// must hold the mutex.
+func (l *MemoryRevocationList) pruneTokens(now time.Time) {
+	for id, expiry := range l.tokens {
+		if now.After(expiry) {
+			delete(l.tokens, id)
+		}
+	}
+}
+
+func (l *MemoryRevocationList) RevokeUserTokens(ctx context.Context, userID string, before time.Time) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	before = before.Truncate(time.Second)
+	if before.After(l.userCutoff[userID]) {
+		l.userCutoff[userID] = before
+	}
+	return nil
+}
+
+func (l *MemoryRevocationList) IsRevoked(ctx context.Context, claims *Claims) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if claims.ID != "" {
+		if expiry, ok := l.tokens[claims.ID]; ok && time.Now().Before(expiry) {
+			return true, nil
+		}
+	}
+
+	if cutoff, ok := l.userCutoff[claims.UserID]; ok {
+		return issuedBefore(claims, cutoff), nil
+	}
+	return false, nil
+}
+
+func (l *MemoryRevocationList) Close() error {
+	return nil
+}
+
+// issuedBefore reports whether the token was issued before the second of
+// cutoff. Tokens without an iat claim count as issued before any cutoff.
+func issuedBefore(claims *Claims, cutoff time.Time) bool {
+	if claims.IssuedAt == nil {
+		return true
+	}
+	return claims.IssuedAt.Unix() < cutoff.Unix()
+}
@@ -0,0 +1,127 @@
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// Prefixes of the revocation keys.
+	revokedTokenKeyPrefix = "auth:revoked:jti:"
+	revokedUserKeyPrefix  = "auth:revoked:user:"
+)
+
+// revokeUserScript stores the cutoff in ARGV[1] under KEYS[1] for ARGV[2]
+// milliseconds unless a later cutoff is stored already.
+var revokeUserScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]))
+if current ~= nil and current >= tonumber(ARGV[1]) then
+	return 0
+end
+
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+
+// RedisRevocationList shares revocations between gateway replicas and the
+// notification service through Redis. Revoked jtis expire with their token;
+// per-user cutoffs are kept for userCutoffTTL, which must cover the longest
+// token lifetime. A list that only checks revocations may pass 0.
+type RedisRevocationList struct {
+	client        *redis.Client
+	userCutoffTTL time.Duration
+}
+
+func NewRedisRevocationList(redisURL string, userCutoffTTL time.Duration) (*RedisRevocationList, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	zap.L().Info("Connected to Redis revocation list")
+
+	return &RedisRevocationList{
+		client:        client,
+		userCutoffTTL: userCutoffTTL,
+	}, nil
+}
+
+func (l *RedisRevocationList) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := l.client.Set(ctx, revokedTokenKeyPrefix+jti, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+func (l *RedisRevocationList) ClaimToken(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return false, nil
+	}
+
+	claimed, err := l.client.SetNX(ctx, revokedTokenKeyPrefix+jti, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim token: %w", err)
+	}
+	return claimed, nil
+}
+
+func (l *RedisRevocationList) RevokeUserTokens(ctx context.Context, userID string, before time.Time) error {
+	keys := []string{revokedUserKeyPrefix + userID}
+	err := revokeUserScript.Run(ctx, l.client, keys, before.Unix(), l.userCutoffTTL.Milliseconds()).Err()
+	if err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+	return nil
+}
+
+func (l *RedisRevocationList) IsRevoked(ctx context.Context, claims *Claims) (bool, error) {
+	keys := []string{revokedUserKeyPrefix + claims.UserID}
+	if claims.ID != "" {
+		keys = append(keys, revokedTokenKeyPrefix+claims.ID)
+	}
+
+	values, err := l.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check revocation: %w", err)
+	}
+
+	if len(values) > 1 && values[1] != nil {
+		return true, nil
+	}
+
+	if cutoff, ok := values[0].(string); ok {
+		seconds, err := strconv.ParseInt(cutoff, 10, 64)
+		if err != nil {
+			return false, errors.New("invalid revocation cutoff")
+		}
+		return issuedBefore(claims, time.Unix(seconds, 0)), nil
+	}
+
+	return false, nil
+}
+
+func (l *RedisRevocationList) Close() error {
+	return l.client.Close()
+}
@@ -0,0 +1,96 @@
+package jwtauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestMemoryRevocationList(t *testing.T) {
+	ctx := context.Background()
+	logout := time.Date(2026, 1, 2, 3, 4, 5, 600_000_000, time.UTC)
+
+	token := func(id, userID string, issued time.Time) *Claims {
+		claims := &Claims{UserID: userID}
+		claims.ID = id
+		if !issued.IsZero() {
+			claims.IssuedAt = jwt.NewNumericDate(issued)
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name        string
+		revokeToken string
+		cutoffs     []time.Time
+		claims      *Claims
+		want        bool
+	}{
+		{name: "not revoked", claims: token("t1", "alice", logout)},
+		{name: "revoked jti", revokeToken: "t1", claims: token("t1", "alice", logout), want: true},
+		{name: "other jti", revokeToken: "t2", claims: token("t1", "alice", logout)},
+		{name: "issued a second before the cutoff", cutoffs: []time.Time{logout}, claims: token("t1", "alice", logout.Add(-time.Second)), want: true},
+		{name: "issued during the cutoff second", cutoffs: []time.Time{logout}, claims: token("t1", "alice", logout.Truncate(time.Second))},
+		{name: "issued after the cutoff", cutoffs: []time.Time{logout}, claims: token("t1", "alice", logout.Add(time.Second))},
+		{name: "no issue time", cutoffs: []time.Time{logout}, claims: token("t1", "alice", time.Time{}), want: true},
+		{name: "other user", cutoffs: []time.Time{logout}, claims: token("t1", "bob", logout.Add(-time.Hour))},
+		{
+			name:    "earlier cutoff does not lower a later one",
+			cutoffs: []time.Time{logout, logout.Add(-time.Hour)},
+			claims:  token("t1", "alice", logout.Add(-time.Minute)),
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewMemoryRevocationList()
+			if tt.revokeToken != "" {
+				if err := l.RevokeToken(ctx, tt.revokeToken, time.Now().Add(time.Hour)); err != nil {
+					t.Fatalf("RevokeToken: %v", err)
+				}
+			}
+			for _, cutoff := range tt.cutoffs {
+				if err := l.RevokeUserTokens(ctx, "alice", cutoff); err != nil {
+					t.Fatalf("RevokeUserTokens: %v", err)
+				}
+			}
+
+			got, err := l.IsRevoked(ctx, tt.claims)
+			if err != nil {
+				t.Fatalf("IsRevoked: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsRevoked = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryRevocationListClaimToken(t *testing.T) {
+	ctx := context.Background()
+	l := NewMemoryRevocationList()
+
+	tests := []struct {
+		name      string
+		jti       string
+		expiresAt time.Time
+		want      bool
+	}{
+		{name: "first claim", jti: "t1", expiresAt: time.Now().Add(time.Hour), want: true},
+		{name: "second claim", jti: "t1", expiresAt: time.Now().Add(time.Hour)},
+		{name: "other token", jti: "t2", expiresAt: time.Now().Add(time.Hour), want: true},
+	}
+
+	for _, tt := range tests {
+		got, err := l.ClaimToken(ctx, tt.jti, tt.expiresAt)
+		if err != nil {
+			t.Fatalf("%s: ClaimToken: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: ClaimToken = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+import { HealthImplementation, ServingStatus } from 'grpc-health-check';
+import { DataSource } from 'typeorm';
+
+// Time between two database checks
+const CHECK_INTERVAL_MS = 10_000;
+
+/**
+ * Serves grpc.health.v1 next to the service's gRPC package. The API gateway
+ * uses it to take instances out of rotation, so the status follows the
+ * database: NOT_SERVING while Postgres does not answer.
+ *
+ * Each service builds its own copy, made in src/shared by its copy:shared
+ * script, so the imports resolve against the service's node_modules.
+ */
+export class GrpcHealth {
+  private readonly health: HealthImplementation;
+  private timer?: NodeJS.Timeout;
+
+  constructor(private readonly services: string[]) {
+    const statuses: Record<string, ServingStatus> = { '': 'NOT_SERVING' };
+    for (const service of services) {
+      statuses[service] = 'NOT_SERVING';
+    }
+    this.health = new HealthImplementation(statuses);
+  }
+
+  // Pass as the gRPC transport's onLoadPackageDefinition option
+  addToServer = (_packageDefinition: unknown, server: any) => {
+    this.health.addToServer(server);
+  };
+
+  watch(dataSource: DataSource) {
+    const check = async () => {
+      let status: ServingStatus = 'SERVING';
+      try {
+        await dataSource.query('SELECT 1');
+      } catch (error) {
+        console.error('Database health check failed:', error);
+        status = 'NOT_SERVING';
+      }
+      this.setStatus(status);
+    };
+
+    void check();
+    this.timer = setInterval(check, CHECK_INTERVAL_MS);
+    this.timer.unref();
+  }
+
+  // Reports NOT_SERVING so clients move away before the server stops
+  shutdown() {
+    clearInterval(this.timer);
+    this.setStatus('NOT_SERVING');
+  }
+
+  private setStatus(status: ServingStatus) {
+    this.health.setStatus('', status);
+    for (const service of this.services) {
+      this.health.setStatus(service, status);
+    }
+  }
+}